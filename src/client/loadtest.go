@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jxbdlut/leaf/log"
+	"server/proto"
+	"server/sdk"
+)
+
+// 压测: 同时开几张桌子, 每张桌子第一个人建桌, 其他人加入, 都是自动打牌的agent.
+// 统计每种请求的往返时间(SendRcv), 出错次数和收到的消息数, 用来估算一台服务器能撑多少桌
+type loadConfig struct {
+	Addr      string
+	Tables    int
+	PlayerNum int
+	Area      int32 // 小于0用服务器配置的默认区域
+	TableType proto.CreateTableReq_TableType
+	Think     time.Duration // 每次做决定之前等多久, 实际在0.5到1.5倍之间随机
+	Duration  time.Duration // 0表示每张桌子打完一次就结束, 否则打完的桌子换新的接着打, 到时间停
+	Report    time.Duration // 多久打一行进度, 0不打
+}
+
+func (c *loadConfig) Validate() error {
+	if c.Tables <= 0 {
+		return fmt.Errorf("tables must be positive, got %v", c.Tables)
+	}
+	if c.PlayerNum < 2 || c.PlayerNum > 4 {
+		return fmt.Errorf("players must be 2 to 4, got %v", c.PlayerNum)
+	}
+	if c.Think < 0 || c.Duration < 0 || c.Report < 0 {
+		return fmt.Errorf("think, duration and report must not be negative")
+	}
+	return nil
+}
+
+// 机器人桌只连建桌的那个人, 其他座位服务器放机器人
+func (c *loadConfig) ConnNum() int {
+	if c.TableType == proto.CreateTableReq_TableRobot {
+		return 1
+	}
+	return c.PlayerNum
+}
+
+func parseTableType(s string) (proto.CreateTableReq_TableType, error) {
+	switch s {
+	case "normal":
+		return proto.CreateTableReq_TableNomal, nil
+	case "robot":
+		return proto.CreateTableReq_TableRobot, nil
+	}
+	return 0, fmt.Errorf("unknown table type %q, want normal or robot", s)
+}
+
+type latencyStats struct {
+	samples []time.Duration
+	errors  int
+}
+
+type metrics struct {
+	sync.Mutex
+	start    time.Time
+	rtt      map[string]*latencyStats
+	received map[string]int
+	errors   map[string]int // 不属于某个请求的错误, 按原因
+	hands    int
+	tables   int
+	finished int
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		start:    time.Now(),
+		rtt:      make(map[string]*latencyStats),
+		received: make(map[string]int),
+		errors:   make(map[string]int),
+	}
+}
+
+// Observe, Receive和Error是sdk.Observer, 每个连接都报到同一个metrics里
+func (m *metrics) Observe(name string, d time.Duration, err error) {
+	m.Lock()
+	defer m.Unlock()
+	stats, ok := m.rtt[name]
+	if !ok {
+		stats = &latencyStats{}
+		m.rtt[name] = stats
+	}
+	if err != nil {
+		stats.errors++
+		return
+	}
+	stats.samples = append(stats.samples, d)
+}
+
+func (m *metrics) Receive(name string) {
+	m.Lock()
+	m.received[name]++
+	m.Unlock()
+}
+
+func (m *metrics) Error(reason string) {
+	m.Lock()
+	m.errors[reason]++
+	m.Unlock()
+}
+
+func (m *metrics) Hand() {
+	m.Lock()
+	m.hands++
+	m.Unlock()
+}
+
+func (m *metrics) TableStarted() {
+	m.Lock()
+	m.tables++
+	m.Unlock()
+}
+
+func (m *metrics) TableFinished() {
+	m.Lock()
+	m.finished++
+	m.Unlock()
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1f", float64(d)/float64(time.Millisecond))
+}
+
+func sortedKeys(m map[string]int) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 一行进度
+func (m *metrics) Summary() string {
+	m.Lock()
+	defer m.Unlock()
+	elapsed := time.Since(m.start)
+	total, errors := 0, 0
+	for _, num := range m.received {
+		total += num
+	}
+	for _, stats := range m.rtt {
+		errors += stats.errors
+	}
+	for _, num := range m.errors {
+		errors += num
+	}
+	return fmt.Sprintf("%v tables:%v/%v hands:%v msgs:%v (%.1f/s) errors:%v",
+		elapsed.Truncate(time.Second), m.finished, m.tables, m.hands, total, float64(total)/elapsed.Seconds(), errors)
+}
+
+func (m *metrics) Report(w io.Writer) {
+	m.Lock()
+	defer m.Unlock()
+	elapsed := time.Since(m.start)
+	fmt.Fprintf(w, "duration:%v, tables:%v (finished:%v), hands:%v, hands/s:%.2f\n",
+		elapsed.Truncate(time.Millisecond), m.tables, m.finished, m.hands, float64(m.hands)/elapsed.Seconds())
+	var names []string
+	for name := range m.rtt {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "%-16s %8s %6s %8s %8s %8s %8s\n", "rtt(ms)", "count", "err", "p50", "p90", "p99", "max")
+	for _, name := range names {
+		stats := m.rtt[name]
+		sorted := append([]time.Duration(nil), stats.samples...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		fmt.Fprintf(w, "%-16s %8v %6v %8v %8v %8v %8v\n", name, len(sorted), stats.errors,
+			ms(percentile(sorted, 0.5)), ms(percentile(sorted, 0.9)), ms(percentile(sorted, 0.99)), ms(percentile(sorted, 1)))
+	}
+	total := 0
+	var items []string
+	for _, name := range sortedKeys(m.received) {
+		total += m.received[name]
+		items = append(items, fmt.Sprintf("%v:%v", name, m.received[name]))
+	}
+	fmt.Fprintf(w, "received:%v, %.1f msg/s, %v\n", total, float64(total)/elapsed.Seconds(), strings.Join(items, " "))
+	items = items[:0]
+	for _, reason := range sortedKeys(m.errors) {
+		items = append(items, fmt.Sprintf("%v:%v", reason, m.errors[reason]))
+	}
+	if len(items) > 0 {
+		fmt.Fprintf(w, "errors: %v\n", strings.Join(items, " "))
+	}
+}
+
+var next_uid uint64
+
+// 一张压测的桌子, 建桌的人断开就算这张桌子打完了
+type loadTable struct {
+	config  *loadConfig
+	metrics *metrics
+	clients []*sdk.Client
+}
+
+func newLoadTable(config *loadConfig, m *metrics) *loadTable {
+	return &loadTable{config: config, metrics: m}
+}
+
+// 连上并登录, 都是自动打牌的机器人. 建桌的人要事件, 用来数发了几次牌和知道什么时候断开
+func (t *loadTable) login(master bool) (*sdk.Client, error) {
+	uid := atomic.AddUint64(&next_uid, 1)
+	opts := sdk.Options{
+		Heartbeat: sdk.HeartbeatInterval,
+		Decider:   sdk.Bot{},
+		Observer:  t.metrics,
+	}
+	if think := t.config.Think; think > 0 {
+		// 只在读消息的goroutine里调用, 不用加锁
+		r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(uid)))
+		opts.Delay = func() time.Duration {
+			return think/2 + time.Duration(r.Int63n(int64(think)))
+		}
+	}
+	if master {
+		opts.Events = 64
+	}
+	c, err := sdk.Dial(t.config.Addr, uid, opts)
+	if err != nil {
+		t.metrics.Error("connect")
+		return nil, err
+	}
+	t.clients = append(t.clients, c)
+	if _, err := c.Login(); err != nil {
+		t.metrics.Error("login")
+		return nil, err
+	}
+	return c, nil
+}
+
+// 出错的时候返回err, 桌子上的连接都断开
+func (t *loadTable) Run(stop chan struct{}) error {
+	defer t.Close()
+	master, err := t.login(true)
+	if err != nil {
+		return err
+	}
+	tid, err := master.CreateTable(&proto.CreateTableReq{
+		Type:      int32(t.config.TableType),
+		Area:      t.config.Area,
+		AreaSet:   t.config.Area >= 0,
+		PlayerNum: int32(t.config.PlayerNum),
+	})
+	if err != nil {
+		log.Error("uid:%v CreateTable err:%v", master.Uid, err)
+		t.metrics.Error("create table")
+		return err
+	}
+	for i := 1; i < t.config.ConnNum(); i++ {
+		c, err := t.login(false)
+		if err != nil {
+			return err
+		}
+		if _, err := c.JoinTable(tid); err != nil {
+			log.Error("uid:%v JoinTable err:%v", c.Uid, err)
+			t.metrics.Error("join table")
+			return err
+		}
+	}
+	for {
+		select {
+		case ev, ok := <-master.Events():
+			if !ok {
+				return nil
+			}
+			if req, ok := ev.Msg.(*proto.OperatReq); ok && req.Type&proto.OperatType_DealOperat != 0 {
+				t.metrics.Hand()
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+func (t *loadTable) Close() {
+	for _, c := range t.clients {
+		c.Close()
+	}
+}
+
+func runTable(config *loadConfig, m *metrics, stop chan struct{}) {
+	for {
+		m.TableStarted()
+		err := newLoadTable(config, m).Run(stop)
+		m.TableFinished()
+		if config.Duration == 0 {
+			return
+		}
+		if err != nil {
+			// 连不上的时候等一会再开新桌子, 不要一直重试
+			select {
+			case <-stop:
+				return
+			case <-time.After(3 * time.Second):
+			}
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+func runLoadTest(config *loadConfig, w io.Writer) {
+	next_uid = uint64(rand.New(rand.NewSource(time.Now().UnixNano())).Intn(math.MaxInt32))
+	m := newMetrics()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < config.Tables; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runTable(config, m, stop)
+		}()
+	}
+	all_done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(all_done)
+	}()
+
+	var deadline, report <-chan time.Time
+	if config.Duration > 0 {
+		deadline = time.After(config.Duration)
+	}
+	if config.Report > 0 {
+		ticker := time.NewTicker(config.Report)
+		defer ticker.Stop()
+		report = ticker.C
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, os.Kill)
+	defer signal.Stop(sig)
+loop:
+	for {
+		select {
+		case <-report:
+			fmt.Fprintln(w, m.Summary())
+		case <-deadline:
+			break loop
+		case s := <-sig:
+			log.Release("load test closing down (signal: %v)", s)
+			break loop
+		case <-all_done:
+			break loop
+		}
+	}
+	close(stop)
+	<-all_done
+	m.Report(w)
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/jxbdlut/leaf/log"
+	"server/proto"
+	"server/sdk"
+	"server/utils"
+)
+
+// 给人打的终端客户端, 手工测试用, 不用手机app.
+// 服务器的消息和键盘输入都放进ui的主循环里处理, 服务器要操作的时候列出选项, 输入编号或者牌来选.
+// 桌面和手牌用sdk镜像的, 发牌摸牌sdk自动确认
+const tuiHelp = `命令:
+  areas                         列出区域
+  create [area] [人数] [robot]   建桌, 加robot是和机器人打
+  join <tid>                    加入桌子
+  trustee on|off                托管/取消托管, 超时之后服务器会自动托管
+  show                          重新画一遍桌面
+  style chinese|notation|glyph  牌的写法
+  help                          帮助
+  quit                          退出
+轮到自己的时候输入选项的编号, 出牌也可以直接输入牌, 例如 5m`
+
+type tuiOption struct {
+	desc string
+	card int32 // 出牌的选项, 用来按牌选
+	rsp  *proto.OperatRsp
+}
+
+// 桌面在sdk里按消息改好了, 这里只管显示和回复
+type tui struct {
+	c         *sdk.Client
+	in        io.Reader
+	out       io.Writer
+	lines     chan string
+	req       *proto.OperatReq
+	req_seq   uint32
+	options   []tuiOption
+	table_req *proto.TableOperatReq
+	table_seq uint32
+}
+
+func newTui(c *sdk.Client) *tui {
+	return &tui{c: c, in: os.Stdin, out: os.Stdout, lines: make(chan string)}
+}
+
+func runTui(uid uint64, name string) error {
+	// 事件要等主循环处理, 主循环等服务器回复的时候读消息的goroutine不能被卡住
+	c, err := sdk.Dial(*addr, uid, sdk.Options{Name: name, Heartbeat: sdk.HeartbeatInterval, Events: 64})
+	if err != nil {
+		return err
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, os.Kill)
+	defer signal.Stop(sig)
+	go func() {
+		<-sig
+		c.Close()
+	}()
+	newTui(c).Run()
+	return nil
+}
+
+func (t *tui) printf(format string, a ...interface{}) {
+	fmt.Fprintf(t.out, format+"\n", a...)
+}
+
+func (t *tui) readLines() {
+	scanner := bufio.NewScanner(t.in)
+	for scanner.Scan() {
+		t.lines <- strings.TrimSpace(scanner.Text())
+	}
+	close(t.lines)
+}
+
+func (t *tui) Run() {
+	need_recover, err := t.c.Login()
+	if err != nil {
+		t.printf("登录失败: %v", err)
+		t.c.Close()
+		return
+	}
+	t.printf("uid:%v 登录成功", t.c.Uid)
+	if need_recover {
+		t.printf("上次的桌子还没打完, 服务器还不支持恢复")
+	}
+	t.printf("%v", tuiHelp)
+	go t.readLines()
+	for {
+		select {
+		case ev, ok := <-t.c.Events():
+			if !ok {
+				t.printf("和服务器断开了")
+				return
+			}
+			t.handle(ev.Msg, ev.Seq)
+		case line, ok := <-t.lines:
+			if !ok || line == "quit" {
+				t.c.Close()
+				return
+			}
+			t.command(line)
+		}
+	}
+}
+
+func (t *tui) command(line string) {
+	if line == "" {
+		return
+	}
+	if t.table_req != nil {
+		t.chooseTableOperat(line)
+		return
+	}
+	if t.req != nil && t.choose(line) {
+		return
+	}
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "help":
+		t.printf("%v", tuiHelp)
+	case "show":
+		t.c.Table.Lock()
+		t.render()
+		t.c.Table.Unlock()
+		t.prompt()
+	case "areas":
+		t.listAreas()
+	case "create":
+		t.createTable(fields[1:])
+	case "join":
+		if len(fields) != 2 {
+			t.printf("usage: join <tid>")
+			return
+		}
+		tid, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			t.printf("invalid tid %q", fields[1])
+			return
+		}
+		t.joinTable(uint32(tid))
+	case "trustee":
+		if len(fields) != 2 || (fields[1] != "on" && fields[1] != "off") {
+			t.printf("usage: trustee on|off")
+			return
+		}
+		if err := t.c.Trustee(fields[1] == "on"); err != nil {
+			t.printf("托管失败: %v", err)
+		}
+	case "style":
+		if len(fields) != 2 {
+			t.printf("usage: style chinese|notation|glyph")
+			return
+		}
+		utils.LogCardStyle = fields[1]
+		t.c.Table.Lock()
+		t.render()
+		t.c.Table.Unlock()
+	default:
+		t.printf("不认识的命令 %q, 输入help看帮助", line)
+	}
+}
+
+func (t *tui) listAreas() {
+	rsp, err := t.c.GetAreas()
+	if err != nil {
+		t.printf("查区域失败: %v", err)
+		return
+	}
+	for _, area := range rsp.Areas {
+		def := ""
+		if area.AreaId == rsp.AreaId {
+			def = " (默认)"
+		}
+		t.printf("  %v %v 人数:%v%v", area.AreaId, area.AreaName, area.PlayerNums, def)
+	}
+}
+
+func (t *tui) createTable(args []string) {
+	req := &proto.CreateTableReq{Type: int32(proto.CreateTableReq_TableNomal)}
+	for i, arg := range args {
+		if arg == "robot" {
+			req.Type = int32(proto.CreateTableReq_TableRobot)
+			continue
+		}
+		num, err := strconv.Atoi(arg)
+		if err != nil || i > 1 {
+			t.printf("usage: create [area] [人数] [robot]")
+			return
+		}
+		if i == 0 {
+			req.Area = int32(num)
+			req.AreaSet = true
+		} else {
+			req.PlayerNum = int32(num)
+		}
+	}
+	tid, err := t.c.CreateTable(req)
+	if err != nil {
+		t.printf("建桌失败: %v", err)
+		return
+	}
+	t.printf("建桌成功, tid:%v, 别人用 join %v 加入", tid, tid)
+}
+
+func (t *tui) joinTable(tid uint32) {
+	pos, err := t.c.JoinTable(tid)
+	if err != nil {
+		t.printf("加入失败: %v", err)
+		return
+	}
+	t.printf("加入桌子%v, 座位:%v", tid, pos)
+}
+
+// 调用的时候桌面已经锁上
+func (t *tui) seatName(uid uint64) string {
+	if uid == t.c.Uid {
+		return "我"
+	}
+	if seat, ok := t.c.Table.Seats[uid]; ok && seat.Name != "" {
+		return seat.Name
+	}
+	return strconv.FormatUint(uid, 10)
+}
+
+// 消息已经改到桌面上了, 这里只打出来
+func (t *tui) handle(msg interface{}, seq uint32) {
+	t.c.Table.Lock()
+	defer t.c.Table.Unlock()
+	switch m := msg.(type) {
+	case *proto.UserJoinTableMsg:
+		t.printf("入座: %v人", len(m.Seats))
+	case *proto.DiceMsg:
+		if m.Wall != nil {
+			t.printf("掷骰子: %v, 庄:%v", m.Wall.Dice, t.seatName(m.Dealer))
+		}
+	case *proto.FlowerMsg:
+		t.printf("%v 补花%v", t.seatName(m.Uid), utils.CardsStr(m.Flowers))
+	case *proto.PlayerStatusMsg:
+		t.printf("%v %v", t.seatName(m.Uid), statusStr(m.Status))
+		// 超时被托管之后服务器不等我们回复了
+		if m.Uid == t.c.Uid && m.Status == proto.PlayerStatus_StatusTrustee {
+			t.req, t.options = nil, nil
+		}
+	case *proto.TableOperatReq:
+		t.table_req, t.table_seq = m, seq
+		t.printf("%v? (y/n)", tableOperatStr(m.Type))
+	case *proto.TableOperatMsg:
+		ok := "同意"
+		if !m.OK {
+			ok = "不同意"
+		}
+		t.printf("%v %v%v", t.seatName(m.Uid), ok, tableOperatStr(m.Type))
+	case *proto.OperatMsg:
+		t.operatMsg(m)
+	case *proto.OperatReq:
+		t.operatReq(m, seq)
+	default:
+		log.Debug("uid:%v, tui ignore msg:%v", t.c.Uid, m)
+	}
+}
+
+func statusStr(status proto.PlayerStatus) string {
+	switch status {
+	case proto.PlayerStatus_StatusOnline:
+		return "在线"
+	case proto.PlayerStatus_StatusOffline:
+		return "掉线"
+	case proto.PlayerStatus_StatusTrustee:
+		return "托管"
+	case proto.PlayerStatus_StatusReconnect:
+		return "重连"
+	}
+	return status.String()
+}
+
+func tableOperatStr(t proto.TableOperat) string {
+	if t == proto.TableOperat_TableContinue {
+		return "继续下一局"
+	}
+	return "开始"
+}
+
+func (t *tui) chooseTableOperat(line string) {
+	if line != "y" && line != "n" {
+		t.printf("%v? (y/n)", tableOperatStr(t.table_req.Type))
+		return
+	}
+	t.c.Reply(&proto.TableOperatRsp{Type: t.table_req.Type, Ok: line == "y"}, t.table_seq)
+	t.table_req = nil
+}
+
+func (t *tui) operatMsg(m *proto.OperatMsg) {
+	switch {
+	case m.Type&proto.OperatType_HuOperat != 0 && m.Hu.Ok:
+		lose := "自摸"
+		if m.Hu.Lose != 0 && m.Hu.Lose != m.Uid {
+			lose = t.seatName(m.Hu.Lose) + "放炮"
+		}
+		t.printf("%v 胡 %v, %v, %v", t.seatName(m.Uid), utils.CardStr(m.Hu.Card), proto.HuTypeStr(m.Hu.Type), lose)
+	case m.Type&proto.OperatType_GangOperat != 0 && m.Gang.Ok && m.Gang.Gang != nil:
+		t.printf("%v %v %v", t.seatName(m.Uid), proto.GangTypeStr(m.Gang.Gang.Type), utils.CardsStr(m.Gang.Gang.Cards))
+	case m.Type&proto.OperatType_PongOperat != 0 && m.Pong.Ok:
+		t.printf("%v 碰 %v", t.seatName(m.Uid), utils.CardStr(m.Pong.Card))
+	case m.Type&proto.OperatType_EatOperat != 0 && m.Eat.Ok && m.Eat.Eat != nil:
+		t.printf("%v 吃 %v", t.seatName(m.Uid), utils.CardsStr(m.Eat.Eat.WaveCard))
+	case m.Type&proto.OperatType_DropOperat != 0:
+		t.printf("%v 打 %v", t.seatName(m.Uid), utils.CardStr(m.Drop.DisCard))
+	}
+}
+
+// 发牌摸牌sdk已经确认过了, 托管的时候sdk也不回
+func (t *tui) operatReq(req *proto.OperatReq, seq uint32) {
+	if req.Type&proto.OperatType_DealOperat != 0 {
+		t.printf("发牌")
+		t.render()
+		return
+	}
+	if req.Type&proto.OperatType_DrawOperat != 0 {
+		return
+	}
+	if t.c.Table.Trustee() {
+		t.printf("托管中: %v", req.Info())
+		return
+	}
+	t.req, t.req_seq = req, seq
+	t.options = t.buildOptions(req)
+	t.render()
+	t.prompt()
+}
+
+// 按胡杠碰吃出牌的顺序列选项, 没有出牌的时候最后一个是过
+func (t *tui) buildOptions(req *proto.OperatReq) []tuiOption {
+	var options []tuiOption
+	add := func(desc string, card int32, fill func(rsp *proto.OperatRsp)) {
+		rsp := proto.NewOperatRsp()
+		fill(rsp)
+		options = append(options, tuiOption{desc: desc, card: card, rsp: rsp})
+	}
+	if req.Type&proto.OperatType_DingQueOperat != 0 {
+		for suit := int32(1); suit <= 3; suit++ {
+			s := suit
+			add("定缺"+utils.SuitStr(s), 0, func(rsp *proto.OperatRsp) {
+				rsp.Type = proto.OperatType_DingQueOperat
+				rsp.DingQueRsp.Suit = s
+			})
+		}
+		return options
+	}
+	if req.Type&proto.OperatType_HuOperat != 0 {
+		hu := req.HuReq
+		add("胡 "+utils.CardStr(hu.Card), 0, func(rsp *proto.OperatRsp) {
+			rsp.Type = proto.OperatType_HuOperat
+			rsp.HuRsp = &proto.HuRsp{Ok: true, Card: hu.Card, Type: hu.Type, Lose: hu.Lose}
+		})
+	}
+	if req.Type&proto.OperatType_GangOperat != 0 {
+		for _, gang := range req.GangReq.Gang {
+			g := gang
+			add(fmt.Sprintf("杠 %v %v", utils.CardsStr(g.Cards), proto.GangTypeStr(g.Type)), 0, func(rsp *proto.OperatRsp) {
+				rsp.Type = proto.OperatType_GangOperat
+				rsp.GangRsp = &proto.GangRsp{Ok: true, Gang: g}
+			})
+		}
+	}
+	if req.Type&proto.OperatType_PongOperat != 0 {
+		card := req.PongReq.Card
+		add("碰 "+utils.CardStr(card), 0, func(rsp *proto.OperatRsp) {
+			rsp.Type = proto.OperatType_PongOperat
+			rsp.PongRsp = &proto.PongRsp{Ok: true, Card: card}
+		})
+	}
+	if req.Type&proto.OperatType_EatOperat != 0 {
+		for _, eat := range req.EatReq.Eat {
+			e := eat
+			add("吃 "+utils.CardsStr(e.WaveCard), 0, func(rsp *proto.OperatRsp) {
+				rsp.Type = proto.OperatType_EatOperat
+				rsp.EatRsp = &proto.EatRsp{Ok: true, Eat: e}
+			})
+		}
+	}
+	if req.Type&proto.OperatType_DropOperat != 0 {
+		cards := t.c.Table.Hand()
+		for i, card := range cards {
+			if i > 0 && card == cards[i-1] {
+				continue
+			}
+			c := card
+			add("打 "+utils.CardStr(c), c, func(rsp *proto.OperatRsp) {
+				rsp.Type = proto.OperatType_DropOperat
+				rsp.DropRsp.DisCard = c
+			})
+		}
+		return options
+	}
+	return append(options, tuiOption{desc: "过", rsp: sdk.Pass(req)})
+}
+
+// 输入的是编号或者牌, 不是选项的时候返回false, 当普通命令处理
+func (t *tui) choose(line string) bool {
+	index := -1
+	if n, err := strconv.Atoi(line); err == nil {
+		index = n - 1
+	} else if cards, err := utils.ParseCards(line); err == nil && len(cards) == 1 {
+		for i, option := range t.options {
+			if option.card != 0 && option.card == cards[0] {
+				index = i
+			}
+		}
+		if index == -1 {
+			t.printf("不能打%v", utils.CardStr(cards[0]))
+			return true
+		}
+	} else {
+		return false
+	}
+	if index < 0 || index >= len(t.options) {
+		t.printf("没有选项%v", line)
+		return true
+	}
+	option := t.options[index]
+	log.Debug("uid:%v, tui %v, %v", t.c.Uid, t.req.Info(), option.rsp.Info())
+	t.c.Reply(option.rsp, t.req_seq)
+	t.req, t.options = nil, nil
+	return true
+}
+
+func (t *tui) prompt() {
+	if t.req == nil {
+		return
+	}
+	var items []string
+	for i, option := range t.options {
+		items = append(items, fmt.Sprintf("%v.%v", i+1, option.desc))
+	}
+	t.printf("轮到你: %v", strings.Join(items, "  "))
+}
+
+// 调用的时候桌面已经锁上
+func (t *tui) render() {
+	table := t.c.Table
+	if table.Tid == 0 {
+		t.printf("还没有进桌子, 用create或者join")
+		return
+	}
+	header := fmt.Sprintf("======== 桌子:%v", table.Tid)
+	if table.RoundWind != 0 {
+		header += " 圈风:" + utils.CardStr(table.RoundWind)
+	}
+	if table.Dealer != 0 {
+		header += " 庄:" + t.seatName(table.Dealer)
+	}
+	t.printf("%v ========", header)
+	for _, seat := range table.SortedSeats() {
+		line := fmt.Sprintf("%v. %v", seat.Pos, t.seatName(seat.Uid))
+		if seat.Wind != 0 {
+			line += " [" + utils.CardStr(seat.Wind) + "]"
+		}
+		if seat.Status != proto.PlayerStatus_StatusOnline {
+			line += " " + statusStr(seat.Status)
+		}
+		if seat.Win {
+			line += " 已胡"
+		}
+		for _, wave := range seat.Waves {
+			line += " " + utils.CardsStr(wave.Cards)
+		}
+		if len(seat.Flowers) > 0 {
+			line += " 花" + utils.CardsStr(seat.Flowers)
+		}
+		t.printf("%v", line)
+		if len(seat.Drops) > 0 {
+			t.printf("     牌河:%v", utils.CardsStr(seat.Drops))
+		}
+	}
+	if len(table.Cards) > 0 {
+		line := "手牌:" + utils.CardsStr(table.Hand())
+		if table.DrawCard != 0 {
+			line += " 摸:" + utils.CardStr(table.DrawCard)
+		}
+		if table.HunCard != 0 {
+			line += " 混:" + utils.CardStr(table.HunCard)
+		}
+		if que := table.Que(); que != 0 {
+			line += " 缺:" + utils.SuitStr(que)
+		}
+		t.printf("%v", line)
+	}
+}
@@ -2,29 +2,150 @@ package conf
 
 import (
 	"encoding/json"
-	"github.com/jxbdlut/leaf/log"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"strings"
 )
 
+const (
+	ProcessorProtobuf = "protobuf"
+	ProcessorJSON     = "json"
+)
+
+// 玩法相关配置
+type GameConf struct {
+	OperatTimeout    int // 等待玩家操作的秒数, 超时之后掉线托管
+	NormalHands      int // 普通桌打多少局
+	RobotHands       int // 机器人桌打多少局
+	RobotNum         int // 机器人桌坐几个机器人, 0表示坐满
+	DefaultArea      int // 建桌没有指定区域时使用的区域
+	DefaultPlayerNum int // 建桌没有指定人数时使用的人数
+	MinTableId       uint32
+	MaxTableId       uint32
+	MinRobotId       uint64
+	MaxRobotId       uint64
+	TableRobot       string // 机器人桌默认的机器人策略, 建桌没有指定难度时使用
+	OfflineRobot     string // 玩家掉线或者托管时代打的机器人策略
+	WallSeed         int64  // 洗牌和掷骰子的种子, 0每张桌子随机; 不为0的时候每张桌子都一样, 测试和复现牌局用
+}
+
+// 数据文件相关配置
+type PersistenceConf struct {
+	GameDataPath string // gamedata配置表目录
+}
+
 var Server struct {
-	LogLevel    string
-	LogPath     string
-	WSAddr      string
-	CertFile    string
-	KeyFile     string
-	TCPAddr     string
-	MaxConnNum  int
-	ConsolePort int
-	ProfilePath string
+	LogLevel         string
+	LogPath          string
+	WSAddr           string
+	CertFile         string
+	KeyFile          string
+	TCPAddr          string
+	TCPProcessor     string // 每个监听的编码, protobuf或者json
+	WSProcessor      string
+	HeartbeatTimeout int // 多少秒没有收到客户端消息就断开, 0表示不检查
+	MaxConnNum       int
+	ConsolePort      int
+	ProfilePath      string
+	LogCardStyle     string // 日志里牌的写法, chinese中文, notation简写(123m), glyph麻将字符
+	Game             GameConf
+	Persistence      PersistenceConf
 }
 
 func init() {
-	data, err := ioutil.ReadFile("conf/server.json")
+	Server.LogLevel = "debug"
+	Server.MaxConnNum = 20000
+	Server.TCPProcessor = ProcessorProtobuf
+	Server.WSProcessor = ProcessorJSON
+	Server.HeartbeatTimeout = 30
+	Server.LogCardStyle = "chinese"
+	Server.Game = GameConf{
+		OperatTimeout:    10,
+		NormalHands:      1000,
+		RobotHands:       1,
+		RobotNum:         0,
+		DefaultArea:      0,
+		DefaultPlayerNum: 4,
+		MinTableId:       10000,
+		MaxTableId:       100000,
+		MinRobotId:       100000,
+		MaxRobotId:       1000000,
+		TableRobot:       "defensive",
+		OfflineRobot:     "baseline",
+	}
+	Server.Persistence = PersistenceConf{
+		GameDataPath: "gamedata",
+	}
+}
+
+// 依次读取配置文件, 环境变量覆盖, 再做检查
+func Load(path string) error {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		log.Fatal("%v", err)
+		return err
 	}
 	err = json.Unmarshal(data, &Server)
 	if err != nil {
-		log.Fatal("%v", err)
+		return fmt.Errorf("%v: %v", path, err)
+	}
+	if err = applyEnv(EnvPrefix, &Server); err != nil {
+		return err
+	}
+	return Validate()
+}
+
+func Validate() error {
+	var errs []string
+	check := func(ok bool, format string, a ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf(format, a...))
+		}
+	}
+	switch Server.LogLevel {
+	case "debug", "release", "error", "fatal":
+	default:
+		check(false, "LogLevel: unknown level %q, want debug/release/error/fatal", Server.LogLevel)
+	}
+	check(Server.TCPAddr != "" || Server.WSAddr != "", "TCPAddr/WSAddr: at least one listen address is required")
+	for _, p := range []struct{ name, value string }{{"TCPProcessor", Server.TCPProcessor}, {"WSProcessor", Server.WSProcessor}} {
+		check(p.value == ProcessorProtobuf || p.value == ProcessorJSON, "%v: unknown processor %q, want %v/%v", p.name, p.value, ProcessorProtobuf, ProcessorJSON)
+	}
+	check((Server.CertFile == "") == (Server.KeyFile == ""), "CertFile/KeyFile: must be set together")
+	check(Server.HeartbeatTimeout >= 0, "HeartbeatTimeout: must not be negative, got %v", Server.HeartbeatTimeout)
+	check(Server.MaxConnNum > 0, "MaxConnNum: must be positive, got %v", Server.MaxConnNum)
+	check(Server.ConsolePort >= 0 && Server.ConsolePort <= 65535, "ConsolePort: out of range, got %v", Server.ConsolePort)
+	switch Server.LogCardStyle {
+	case "chinese", "notation", "glyph":
+	default:
+		check(false, "LogCardStyle: unknown style %q, want chinese/notation/glyph", Server.LogCardStyle)
+	}
+
+	g := &Server.Game
+	check(g.OperatTimeout > 0, "Game.OperatTimeout: must be positive, got %v", g.OperatTimeout)
+	check(g.NormalHands > 0, "Game.NormalHands: must be positive, got %v", g.NormalHands)
+	check(g.RobotHands > 0, "Game.RobotHands: must be positive, got %v", g.RobotHands)
+	check(g.RobotNum >= 0 && g.RobotNum <= 3, "Game.RobotNum: must be in [0, 3], got %v", g.RobotNum)
+	check(g.DefaultArea >= 0, "Game.DefaultArea: must not be negative, got %v", g.DefaultArea)
+	check(g.DefaultPlayerNum >= 2 && g.DefaultPlayerNum <= 4, "Game.DefaultPlayerNum: must be in [2, 4], got %v", g.DefaultPlayerNum)
+	check(g.MinTableId < g.MaxTableId, "Game.MinTableId/MaxTableId: empty range [%v, %v]", g.MinTableId, g.MaxTableId)
+	check(g.MinRobotId < g.MaxRobotId, "Game.MinRobotId/MaxRobotId: empty range [%v, %v]", g.MinRobotId, g.MaxRobotId)
+	check(g.TableRobot != "", "Game.TableRobot: is required")
+	check(g.OfflineRobot != "", "Game.OfflineRobot: is required")
+
+	check(Server.Persistence.GameDataPath != "", "Persistence.GameDataPath: is required")
+
+	if len(errs) > 0 {
+		return errors.New("invalid config:\n\t" + strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// 生效的配置, 启动的时候打印出来
+func String() string {
+	data, err := json.MarshalIndent(&Server, "", "\t")
+	if err != nil {
+		return err.Error()
 	}
+	return string(data)
 }
@@ -3,11 +3,36 @@ package area
 import (
 	"server/proto"
 	"server/utils"
+	"strings"
+)
+
+// 听牌张型, 一张牌可能有几种拆法, 所以用位组合
+const (
+	WaitShunZi     = 1 << iota // 两面
+	WaitBianZhang              // 边张
+	WaitKaZhang                // 卡张
+	WaitDanDiao                // 单钓
+	WaitDuiDao                 // 对倒
+	WaitQuanQiuRen             // 全求人: 手里只剩一张单钓
+)
+
+var (
+	WaitShapes   = []int{WaitQuanQiuRen, WaitDanDiao, WaitKaZhang, WaitBianZhang, WaitDuiDao, WaitShunZi}
+	WaitShapeMap = map[int]string{WaitShunZi: "两面", WaitBianZhang: "边张", WaitKaZhang: "卡张", WaitDanDiao: "单钓",
+		WaitDuiDao: "对倒", WaitQuanQiuRen: "全求人"}
 )
 
 type Rule interface {
 	HasHun() bool
 	HasWind() bool
+	HasDingQue() bool
+	ValidPlayerNum(player_num int) bool
+	WallCards(player_num int) []int32
+	HandCardNum() int
+	DeadWallNum() int
+	SelectHunCard(draw func() int32) (int32, int32)
+	WinnerNum(player_num int) int
+	MinFan() int
 	IsJiang(card int32) bool
 	CanHu(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool
 	CanEat(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool
@@ -15,10 +40,38 @@ type Rule interface {
 	CanBuGang(player *proto.Player, req *proto.OperatReq) bool
 	CanMingGang(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool
 	CanPong(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool
+	CanDrop(player *proto.Player, card int32) bool
 	Hu(player *proto.Player, huRsp *proto.HuRsp)
-	GetTingCards(player *proto.Player) ([]int32, []int32, map[int32]interface{})
+	Score(ctx *Context, ting Ting, huRsp *proto.HuRsp) (int, []string)
+	GetTingCards(ctx *Context) ([]int32, []int32, map[int32]interface{})
 }
 
 type Ting interface {
 	Info() string
+	WaitShape() int
+	Patterns() []string
+}
+
+func WaitShapeStr(shape int) string {
+	var names []string
+	for _, wait := range WaitShapes {
+		if shape&wait != 0 {
+			names = append(names, WaitShapeMap[wait])
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// 一次听牌分析的上下文, 规则实例不保存任何状态, 分析过程中的中间结果都放在这里,
+// 所以同一个规则实例可以被多个桌子和多个座位同时使用
+type Context struct {
+	Player    *proto.Player
+	Catalog   *Catalog
+	QingYiSe  bool
+	JiangYiSe bool
+	WindYiSe  bool
+}
+
+func NewContext(player *proto.Player, catalog *Catalog) *Context {
+	return &Context{Player: player, Catalog: catalog}
 }
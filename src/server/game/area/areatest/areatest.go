@@ -0,0 +1,137 @@
+// 规则测试用的工具: 用牌的简写构造听牌分析用的proto.Player, 暴力拆牌的参考实现
+package areatest
+
+import (
+	"fmt"
+	"server/game/area"
+	"server/proto"
+	"server/utils"
+	"sort"
+	"strings"
+)
+
+const (
+	Uid      = 1
+	OtherUid = 2
+)
+
+// hand是手牌的简写(见utils.ParseHand), waves是吃碰杠, 每组一个简写, 三张一样是碰, 四张一样是明杠, 三张连着是吃
+func NewPlayer(hand string, hun_card int32, waves ...string) (*proto.Player, error) {
+	cards, err := utils.ParseHand(hand, hun_card)
+	if err != nil {
+		return nil, err
+	}
+	utils.SortCards(cards, hun_card)
+	player := &proto.Player{
+		Uid:            Uid,
+		Cards:          cards,
+		HunCard:        hun_card,
+		NeedHun:        []int32{4, 4, 4, 4},
+		NeedHunWithEye: []int32{4, 4, 4, 4},
+		IsNeedUpdate:   []bool{true, true, true, true},
+		PrewinCards:    make(map[int32]*proto.PreWinCard),
+		Pos:            []*proto.PosMsg{{Uid: Uid}, {Uid: OtherUid}},
+	}
+	for _, s := range waves {
+		wave, err := ParseWave(s)
+		if err != nil {
+			return nil, err
+		}
+		player.Waves = append(player.Waves, wave)
+	}
+	return player, nil
+}
+
+func ParseWave(s string) (*proto.Wave, error) {
+	cards, err := utils.ParseCards(s)
+	if err != nil {
+		return nil, err
+	}
+	same := true
+	for _, card := range cards {
+		same = same && card == cards[0]
+	}
+	switch {
+	case len(cards) == 3 && same:
+		return &proto.Wave{Cards: cards, WaveType: proto.Wave_PongWave}, nil
+	case len(cards) == 4 && same:
+		return &proto.Wave{Cards: cards, WaveType: proto.Wave_GangWave, GangType: proto.GangType_MingGang}, nil
+	case len(cards) == 3 && cards[0]/100 < 4 && cards[1] == cards[0]+1 && cards[2] == cards[0]+2:
+		return &proto.Wave{Cards: cards, WaveType: proto.Wave_EatWave}, nil
+	}
+	return nil, fmt.Errorf("invalid wave %q", s)
+}
+
+// 牌墙里面所有的牌种, 从小到大
+func CardKinds(rule area.Rule) []int32 {
+	var kinds []int32
+	for _, card := range rule.WallCards(4) {
+		if !utils.IsFlower(card) && !utils.Contain(kinds, card) {
+			kinds = append(kinds, card)
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// 按服务器的流程算能胡的牌: GetTingCards的结果放进PrewinCards, 再对每种牌问一遍别人打出来能不能胡
+// 返回能胡的牌和GetTingCards的结果
+func WinCards(rule area.Rule, player *proto.Player) ([]int32, map[int32]interface{}) {
+	_, _, result := rule.GetTingCards(area.NewContext(player, nil))
+	player.PrewinCards = make(map[int32]*proto.PreWinCard)
+	for key := range result {
+		player.PrewinCards[key] = &proto.PreWinCard{Card: key}
+	}
+	var cards []int32
+	for _, card := range CardKinds(rule) {
+		if !CanDraw(player, card) {
+			continue
+		}
+		if rule.CanHu(utils.DisCard{Card: card, FromUid: OtherUid}, player, proto.NewOperatReq()) {
+			cards = append(cards, card)
+		}
+	}
+	return cards, result
+}
+
+// 手里和吃碰杠里已经有四张的牌不可能再摸到
+func CanDraw(player *proto.Player, card int32) bool {
+	num := utils.Count(player.Cards, card)
+	for _, wave := range player.Waves {
+		num += utils.Count(wave.Cards, card)
+	}
+	return num < 4
+}
+
+// 用参考实现算能胡的牌, win判断手牌加上card之后能不能胡, cards里面已经有card
+func RefWinCards(rule area.Rule, player *proto.Player, win func(cards []int32, card int32) bool) []int32 {
+	var cards []int32
+	for _, card := range CardKinds(rule) {
+		if !CanDraw(player, card) {
+			continue
+		}
+		if win(append(utils.Copy(player.Cards), card), card) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// 听牌结果里每张牌的番型, 按牌排好, 例如 "3m:碰碰胡,单钓 6m:两面"
+func FormatPatterns(result map[int32]interface{}) string {
+	var keys []int32
+	for key := range result {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	var items []string
+	for _, key := range keys {
+		name := utils.CardNotation(key)
+		var patterns []string
+		if ting, ok := result[key].(area.Ting); ok {
+			patterns = ting.Patterns()
+		}
+		items = append(items, name+":"+strings.Join(patterns, ","))
+	}
+	return strings.Join(items, " ")
+}
@@ -0,0 +1,105 @@
+package areatest
+
+import (
+	"fmt"
+	"math/rand"
+	"server/game/area"
+	"server/proto"
+	"server/utils"
+	"strings"
+)
+
+// 一条听牌语料
+type Case struct {
+	Hand  string   // 手牌, 例如 "123m456p789s11z + hun x2"
+	Hun   string   // 混牌, 没有混为空
+	Waves []string // 吃碰杠, 每组一个简写
+	Que   int32    // 定缺的门
+	Wind  string   // 门风和圈风
+	Waits string   // 别人打出来能胡的牌, 包括混
+	Tings string   // GetTingCards的结果和每张牌的番型, 格式见FormatPatterns
+}
+
+func (c *Case) String() string {
+	s := c.Hand
+	if c.Hun != "" {
+		s += " hun:" + c.Hun
+	}
+	if len(c.Waves) > 0 {
+		s += " waves:" + strings.Join(c.Waves, ",")
+	}
+	return s
+}
+
+func (c *Case) Player() (*proto.Player, error) {
+	var hun_card int32
+	if c.Hun != "" {
+		cards, err := utils.ParseCards(c.Hun)
+		if err != nil || len(cards) != 1 {
+			return nil, fmt.Errorf("invalid hun %q", c.Hun)
+		}
+		hun_card = cards[0]
+	}
+	player, err := NewPlayer(c.Hand, hun_card, c.Waves...)
+	if err != nil {
+		return nil, err
+	}
+	player.Que = c.Que
+	if c.Wind != "" {
+		cards, err := utils.ParseCards(c.Wind)
+		if err != nil || len(cards) != 1 {
+			return nil, fmt.Errorf("invalid wind %q", c.Wind)
+		}
+		player.SeatWind, player.RoundWind = cards[0], cards[0]
+	}
+	return player, nil
+}
+
+func (c *Case) Check(rule area.Rule) error {
+	player, err := c.Player()
+	if err != nil {
+		return err
+	}
+	cards, result := WinCards(rule, player)
+	if waits := utils.CardsNotation(cards); waits != c.Waits {
+		return fmt.Errorf("%v: waits %q, want %q", c, waits, c.Waits)
+	}
+	if tings := FormatPatterns(result); tings != c.Tings {
+		return fmt.Errorf("%v: tings %q, want %q", c, tings, c.Tings)
+	}
+	return nil
+}
+
+// 参考实现的胡牌判断: cards是加上card之后的手牌, 吃碰杠在player里面
+type WinFunc func(player *proto.Player, cards []int32, card int32) bool
+
+// 随机生成n手牌, 一半是凑出来的听牌, 一半是随手抓的牌, 规则算出来能胡的牌和参考实现不一样就返回错误
+// hun_card给每手牌选混, 为nil表示没有混
+func CrossCheck(rule area.Rule, seed int64, n int, hun_card func(r *rand.Rand) int32, win WinFunc) error {
+	r := rand.New(rand.NewSource(seed))
+	kinds := CardKinds(rule)
+	for i := 0; i < n; i++ {
+		var hun int32
+		if hun_card != nil {
+			hun = hun_card(r)
+		}
+		var player *proto.Player
+		if i%2 == 0 {
+			player = RandomTing(r, kinds, hun, 4, r.Intn(5))
+		} else {
+			player, _ = NewPlayer(utils.CardsNotation(RandomHand(r, kinds, 13)), hun)
+		}
+		got, _ := WinCards(rule, player)
+		want := RefWinCards(rule, player, func(cards []int32, card int32) bool {
+			return win(player, cards, card)
+		})
+		if utils.CardsNotation(got) != utils.CardsNotation(want) {
+			c := Case{Hand: utils.HandNotation(player.Cards, hun), Hun: utils.CardNotation(hun)}
+			for _, wave := range player.Waves {
+				c.Waves = append(c.Waves, utils.CardsNotation(wave.Cards))
+			}
+			return fmt.Errorf("seed:%v, %v: waits %q, reference %q", seed, &c, utils.CardsNotation(got), utils.CardsNotation(want))
+		}
+	}
+	return nil
+}
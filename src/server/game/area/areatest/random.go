@@ -0,0 +1,122 @@
+package areatest
+
+import (
+	"math/rand"
+	"server/proto"
+	"server/utils"
+)
+
+// 随机凑一手胡牌(四组面子一对将, 或者七对), 再随机拿掉一张, 得到一手大概率听牌的手牌
+// kinds是能用的牌种, hun_card不为0的时候手里和hun_card一样的牌都是混, 再另外换进去至多hun_num张混,
+// waves组面子放到吃碰杠里面
+func RandomTing(r *rand.Rand, kinds []int32, hun_card int32, hun_num int, waves int) *proto.Player {
+	for {
+		if player := randomTing(r, kinds, hun_card, hun_num, waves); player != nil {
+			return player
+		}
+	}
+}
+
+func randomTing(r *rand.Rand, kinds []int32, hun_card int32, hun_num int, waves int) *proto.Player {
+	counts := make(map[int32]int)
+	take := func(cards ...int32) bool {
+		for i, card := range cards {
+			if counts[card] == 4 {
+				for _, c := range cards[:i] {
+					counts[c]--
+				}
+				return false
+			}
+			counts[card]++
+		}
+		return true
+	}
+	var groups [][]int32
+	if waves == 0 && r.Intn(8) == 0 {
+		for len(groups) < 7 {
+			card := kinds[r.Intn(len(kinds))]
+			if take(card, card) {
+				groups = append(groups, []int32{card, card})
+			}
+		}
+	} else {
+		for tries := 0; len(groups) < 5; tries++ {
+			if tries > 100 {
+				return nil
+			}
+			card := kinds[r.Intn(len(kinds))]
+			var group []int32
+			switch {
+			case len(groups) == 0:
+				group = []int32{card, card}
+			case card/100 < 4 && card%10 <= 7 && r.Intn(2) == 0 && utils.Contain(kinds, card+2):
+				group = []int32{card, card + 1, card + 2}
+			default:
+				group = []int32{card, card, card}
+			}
+			if take(group...) {
+				groups = append(groups, group)
+			}
+		}
+	}
+	player, _ := NewPlayer("", hun_card)
+	var cards []int32
+	for i, group := range groups {
+		// 第一组是将, 带混的面子不能放到吃碰杠里面
+		if i > 0 && len(player.Waves) < waves && (hun_card == 0 || !utils.Contain(group, hun_card)) {
+			wave := &proto.Wave{Cards: group, WaveType: proto.Wave_EatWave}
+			if group[0] == group[1] {
+				wave.WaveType = proto.Wave_PongWave
+				if r.Intn(3) == 0 && take(group[0]) {
+					wave.Cards = append(wave.Cards, group[0])
+					wave.WaveType, wave.GangType = proto.Wave_GangWave, proto.GangType_MingGang
+				}
+			}
+			player.Waves = append(player.Waves, wave)
+			continue
+		}
+		cards = append(cards, group...)
+	}
+	if hun_card != 0 {
+		for i := r.Intn(hun_num + 1); i > 0 && counts[hun_card] < 4; i-- {
+			j := r.Intn(len(cards))
+			if cards[j] != hun_card {
+				counts[cards[j]]--
+				counts[hun_card]++
+				cards[j] = hun_card
+			}
+		}
+	}
+	j := r.Intn(len(cards))
+	cards = append(cards[:j], cards[j+1:]...)
+	utils.SortCards(cards, hun_card)
+	player.Cards = cards
+	return player
+}
+
+// 随机的手牌, 随机去掉几门让牌集中一点, 大多数不听牌, 用来找误报
+func RandomHand(r *rand.Rand, kinds []int32, num int) []int32 {
+	var suits []int32
+	for _, card := range kinds {
+		if !utils.Contain(suits, card/100) {
+			suits = append(suits, card/100)
+		}
+	}
+	r.Shuffle(len(suits), func(i, j int) { suits[i], suits[j] = suits[j], suits[i] })
+	suits = suits[:1+r.Intn(len(suits))]
+	var pool []int32
+	for _, card := range kinds {
+		if utils.Contain(suits, card/100) {
+			pool = append(pool, card, card, card, card)
+		}
+	}
+	// 剩下的门不够张数就全部都用
+	if len(pool) < num {
+		pool = pool[:0]
+		for _, card := range kinds {
+			pool = append(pool, card, card, card, card)
+		}
+	}
+	r.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	return pool[:num]
+}
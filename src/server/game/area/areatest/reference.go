@@ -0,0 +1,154 @@
+package areatest
+
+// 暴力拆牌的参考实现: 不求快, 只求和牌型的定义一一对应, 用来交叉验证各个规则的GetTingCards
+// 混可以当任何牌, 拆牌的时候每一步都把混当牌和不当牌两种都试一遍
+
+type Counts [5][10]int
+
+// 按门统计张数, 混单独计数
+func CountCards(cards []int32, hun_card int32) (Counts, int) {
+	var counts Counts
+	hun_num := 0
+	for _, card := range cards {
+		if hun_card != 0 && card == hun_card {
+			hun_num++
+			continue
+		}
+		counts[card/100][card%10]++
+	}
+	return counts, hun_num
+}
+
+func (c *Counts) first() (int, int) {
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			if c[t][v] > 0 {
+				return t, v
+			}
+		}
+	}
+	return 0, 0
+}
+
+// 拆成刻子顺子加一对将, eye判断一张牌能不能做将, 两张混做将的时候总能当成一对合法的将
+func IsStandard(counts Counts, hun_num int, eye func(card int32) bool) bool {
+	return split(&counts, hun_num, eye, true, false)
+}
+
+// 碰碰胡: 拆成刻子加一对将
+func IsAllPongs(counts Counts, hun_num int, eye func(card int32) bool) bool {
+	return split(&counts, hun_num, eye, true, true)
+}
+
+// 全部拆成刻子顺子, 没有将
+func IsMelds(counts Counts, hun_num int) bool {
+	return split(&counts, hun_num, nil, false, false)
+}
+
+// 至少要几张混才能全部拆成面子(eye为nil)或者面子加将, 超过max按max算, 和GetNeedHunInSub一样
+func NeedHun(counts Counts, eye func(card int32) bool, max int) int {
+	for hun_num := 0; hun_num < max; hun_num++ {
+		if split(&counts, hun_num, eye, eye != nil, false) {
+			return hun_num
+		}
+	}
+	return max
+}
+
+// 最小的一张牌一定在某一组里: 做将, 做刻子, 或者在顺子的三个位置之一
+func split(c *Counts, hun_num int, eye func(card int32) bool, need_eye bool, pong_only bool) bool {
+	t, v := c.first()
+	if t == 0 {
+		if need_eye {
+			return hun_num >= 2 && (hun_num-2)%3 == 0
+		}
+		return hun_num%3 == 0
+	}
+	card := int32(t*100 + v)
+	if need_eye && eye(card) {
+		for real := 1; real <= 2 && real <= c[t][v]; real++ {
+			if 2-real <= hun_num {
+				c[t][v] -= real
+				ok := split(c, hun_num-(2-real), eye, false, pong_only)
+				c[t][v] += real
+				if ok {
+					return true
+				}
+			}
+		}
+	}
+	for real := 1; real <= 3 && real <= c[t][v]; real++ {
+		if 3-real <= hun_num {
+			c[t][v] -= real
+			ok := split(c, hun_num-(3-real), eye, need_eye, pong_only)
+			c[t][v] += real
+			if ok {
+				return true
+			}
+		}
+	}
+	if pong_only || t == 4 {
+		return false
+	}
+	for start := v - 2; start <= v; start++ {
+		if start < 1 || start > 7 {
+			continue
+		}
+		if splitShunZi(c, t, start, v, 0, hun_num, eye, need_eye, pong_only) {
+			return true
+		}
+	}
+	return false
+}
+
+// 顺子start开始的第i张, v是必须用真牌的那张, 其他位置有真牌用真牌, 也试一下用混
+func splitShunZi(c *Counts, t int, start int, v int, i int, hun_num int, eye func(card int32) bool, need_eye bool, pong_only bool) bool {
+	if i == 3 {
+		return split(c, hun_num, eye, need_eye, pong_only)
+	}
+	p := start + i
+	if c[t][p] > 0 {
+		c[t][p]--
+		ok := splitShunZi(c, t, start, v, i+1, hun_num, eye, need_eye, pong_only)
+		c[t][p]++
+		if ok {
+			return true
+		}
+	}
+	if p != v && hun_num > 0 {
+		return splitShunZi(c, t, start, v, i+1, hun_num-1, eye, need_eye, pong_only)
+	}
+	return false
+}
+
+// 七对: 没有吃碰杠, 14张全是对子, 四张一样的算两对, 单张用混补
+func IsPair7(counts Counts, hun_num int) bool {
+	total, single := hun_num, 0
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			total += counts[t][v]
+			single += counts[t][v] % 2
+		}
+	}
+	return total == 14 && single <= hun_num
+}
+
+var shisanyao_cards = []int32{101, 109, 201, 209, 301, 309, 401, 402, 403, 404, 405, 406, 407}
+
+// 十三幺: 十三种幺九字牌各一张, 其中一种两张, 缺的用混补
+func IsShiSanYao(counts Counts, hun_num int) bool {
+	total, missing := hun_num, 0
+	for _, card := range shisanyao_cards {
+		num := counts[card/100][card%10]
+		if num == 0 {
+			missing++
+		}
+		total += num
+		counts[card/100][card%10] = 0
+	}
+	// 还剩下牌说明有不是幺九的牌
+	if t, _ := counts.first(); t != 0 {
+		return false
+	}
+	return total == 14 && missing <= hun_num
+}
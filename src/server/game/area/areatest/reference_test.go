@@ -0,0 +1,43 @@
+package areatest
+
+import (
+	"server/utils"
+	"testing"
+)
+
+// 参考实现自己先要对
+func TestReference(t *testing.T) {
+	any := func(card int32) bool { return true }
+	cases := []struct {
+		hand     string
+		standard bool
+		pongs    bool
+		pair7    bool
+	}{
+		{"123m456p789s11122z", true, false, false},
+		{"111m222p333s44455z", true, true, false},
+		{"1133m5577p99s1144z", false, false, true},
+		{"1122m3344p5566s77z", false, false, true},
+		{"123m456p789s1112z + hun x1", true, false, false},
+		{"111m444p777s11z + hun x3", true, true, true},
+		{"1357m2468p1357s + hun x2", false, false, false},
+	}
+	for _, c := range cases {
+		// 用一张花当混, 和手牌里的牌分得开
+		cards, _ := utils.ParseHand(c.hand, 508)
+		counts, hun_num := CountCards(cards, 508)
+		if got := IsStandard(counts, hun_num, any); got != c.standard {
+			t.Errorf("IsStandard(%q) = %v", c.hand, got)
+		}
+		if got := IsAllPongs(counts, hun_num, any); got != c.pongs {
+			t.Errorf("IsAllPongs(%q) = %v", c.hand, got)
+		}
+		if got := IsPair7(counts, hun_num); got != c.pair7 {
+			t.Errorf("IsPair7(%q) = %v", c.hand, got)
+		}
+	}
+	counts, _ := CountCards([]int32{101, 109, 201, 209, 301, 309, 401, 402, 403, 404, 405, 406, 407, 407}, 0)
+	if !IsShiSanYao(counts, 0) {
+		t.Error("IsShiSanYao want true")
+	}
+}
@@ -1,5 +1,11 @@
 package base_rule
 
+import (
+	"server/game/area"
+	"server/proto"
+	"server/utils"
+)
+
 type BaseRule struct {
 	has_wind bool
 	has_hun  bool
@@ -16,17 +22,16 @@ func NewBaseRule(has_wind bool, has_hun bool, is_258 bool) *BaseRule {
 
 func (m *BaseRule) IsJiang(card int32) bool {
 	if m.is_258 {
-		t := card / 100
-		v := card % 10
-		if t != 4 && (v == 2 || v == 5 || v == 8) {
-			return true
-		} else {
-			return false
-		}
+		return utils.Card(card).Is258()
 	}
 	return true
 }
 
+// 默认不限起胡番
+func (m *BaseRule) MinFan() int {
+	return 0
+}
+
 func (m *BaseRule) HasHun() bool {
 	return m.has_hun
 }
@@ -35,6 +40,77 @@ func (m *BaseRule) HasWind() bool {
 	return m.has_wind
 }
 
+// 牌墙: 按人数选用的门每张4份, 有风的话再加上東南西北中發白
+func (m *BaseRule) WallCards(player_num int) []int32 {
+	return BuildWall(m.Suits(player_num), m.has_wind, false, 4)
+}
+
+// 四人用万饼条, 两人三人去掉万
+func (m *BaseRule) Suits(player_num int) []int32 {
+	if player_num < 4 {
+		return []int32{2, 3}
+	}
+	return []int32{1, 2, 3}
+}
+
+func (m *BaseRule) ValidPlayerNum(player_num int) bool {
+	return 2 <= player_num && player_num <= 4
+}
+
+// 三人麻将不能吃
+func (m *BaseRule) CanEat(player_num int) bool {
+	return player_num != 3
+}
+
+// 每个人起手的张数
+func (m *BaseRule) HandCardNum() int {
+	return 13
+}
+
+// 牌墙最后留下不摸的张数, 剩这么多就流局
+func (m *BaseRule) DeadWallNum() int {
+	return 10
+}
+
+// 有混的话翻一张牌, 翻出来的下一张是混, 返回翻牌和混牌
+func (m *BaseRule) SelectHunCard(draw func() int32) (int32, int32) {
+	if !m.has_hun {
+		return 0, 0
+	}
+	fan_card := draw()
+	return fan_card, m.NextCard(fan_card)
+}
+
+// 同一门的下一张, 九万的下一张是一万, 白的下一张是東
+func (m *BaseRule) NextCard(card int32) int32 {
+	return int32(utils.Card(card).Next())
+}
+
+// 生成牌墙: suits里面每门一到九, has_wind加上字牌, 每张copies份, has_flower再加上春夏秋冬梅兰竹菊各一张
+func BuildWall(suits []int32, has_wind bool, has_flower bool, copies int) []int32 {
+	var each_cards []int32
+	for _, t := range suits {
+		for v := int32(1); v <= utils.Suit(t).MaxRank(); v++ {
+			each_cards = append(each_cards, int32(utils.NewCard(utils.Suit(t), v)))
+		}
+	}
+	if has_wind {
+		for v := int32(1); v <= utils.SuitZi.MaxRank(); v++ {
+			each_cards = append(each_cards, int32(utils.NewCard(utils.SuitZi, v)))
+		}
+	}
+	var all_cards []int32
+	for i := 0; i < copies; i++ {
+		all_cards = append(all_cards, each_cards...)
+	}
+	if has_flower {
+		for v := int32(1); v <= utils.SuitFlower.MaxRank(); v++ {
+			all_cards = append(all_cards, int32(utils.NewCard(utils.SuitFlower, v)))
+		}
+	}
+	return all_cards
+}
+
 //func (m *BaseRule)HasDuiJiang(cards []int32) bool {
 //	var cache []int32
 //	for _, card := range cards {
@@ -49,23 +125,16 @@ func (m *BaseRule) HasWind() bool {
 //	return false
 //}
 
+// 三张牌能不能组成刻子或者顺子, 字牌只能组成刻子
 func (m *BaseRule) Check3Combine(card1 int32, card2 int32, card3 int32) bool {
-	m1, m2, m3 := card1/100, card2/100, card3/100
-
-	if m1 != m2 || m1 != m3 {
+	c1, c2, c3 := utils.Card(card1), utils.Card(card2), utils.Card(card3)
+	if c1.Suit() != c2.Suit() || c1.Suit() != c3.Suit() {
 		return false
 	}
-	v1, v2, v3 := card1%10, card2%10, card3%10
-	if v1 == v2 && v2 == v3 {
+	if c1 == c2 && c2 == c3 {
 		return true
 	}
-	if m3 == 4 {
-		return false
-	}
-	if v1+1 == v2 && v1+2 == v3 {
-		return true
-	}
-	return false
+	return c3.IsNumber() && c1+1 == c2 && c1+2 == c3
 }
 
 func (m *BaseRule) Check2Combine(card1 int32, card2 int32) bool {
@@ -93,3 +162,22 @@ func (m *BaseRule) GetModNeedNum(len int, eye bool) int32 {
 		return need_hun_arr[len%3]
 	}
 }
+
+// 按番型表计番: 牌型加上胡牌方式, 什么番型都没有就算鸡胡, 没有番型表的时候不计番
+func (m *BaseRule) Score(ctx *area.Context, ting area.Ting, huRsp *proto.HuRsp) (int, []string) {
+	if ctx.Catalog == nil {
+		return 0, nil
+	}
+	var patterns []string
+	if ting != nil {
+		patterns = append(patterns, ting.Patterns()...)
+	}
+	patterns = append(patterns, area.HuPatterns(ctx.Player, huRsp)...)
+	fan, names := ctx.Catalog.Score(patterns)
+	if len(names) == 0 {
+		if def, ok := ctx.Catalog.Get(area.PatternJiHu); ok {
+			return def.Value, []string{def.Name}
+		}
+	}
+	return fan, names
+}
@@ -0,0 +1,203 @@
+package base_rule
+
+import (
+	"server/game/area"
+	"server/proto"
+	"server/utils"
+)
+
+// 听的牌和张型, 各区域的Ting都嵌一个
+type Wait struct {
+	card  int32
+	shape int
+}
+
+func NewWait(card int32) Wait {
+	return Wait{card: card}
+}
+
+func (m *Wait) Card() int32 {
+	return m.card
+}
+
+func (m *Wait) SetWait(shape int) {
+	m.shape = shape
+}
+
+func (m *Wait) WaitShape() int {
+	return m.shape
+}
+
+// 听的牌加上张型
+func (m *Wait) CardInfo() string {
+	if m.shape != 0 {
+		return utils.CardStr(m.card) + "(" + area.WaitShapeStr(m.shape) + ")"
+	}
+	return utils.CardStr(m.card)
+}
+
+// 全部能组成刻子或者顺子, 字牌只能组成刻子, 缺的张用hun_num张混补
+func IsAllMelds(counts utils.Hand, hun_num int) bool {
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v] == 0 {
+				continue
+			}
+			// 最小的一张要么做刻子, 要么在顺子里面, 比它小的位置只能是混
+			num := counts[t][v]
+			if num > 3 {
+				num = 3
+			}
+			if need := 3 - num; need <= hun_num {
+				counts[t][v] -= num
+				if IsAllMelds(counts, hun_num-need) {
+					return true
+				}
+				counts[t][v] += num
+			}
+			if t == 4 {
+				return false
+			}
+			for start := v - 2; start <= v; start++ {
+				if start < 1 || start > 7 {
+					continue
+				}
+				need := 0
+				for i := start; i < start+3; i++ {
+					if counts[t][i] == 0 {
+						need++
+					}
+				}
+				if need > hun_num {
+					continue
+				}
+				next := counts
+				for i := start; i < start+3; i++ {
+					if next[t][i] > 0 {
+						next[t][i]--
+					}
+				}
+				if IsAllMelds(next, hun_num-need) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return hun_num%3 == 0
+}
+
+// 一对将加上刻子顺子, 将可以是一对, 一张加一个混, 或者两个混
+func IsPairMelds(counts utils.Hand, hun_num int) bool {
+	if hun_num >= 2 && IsAllMelds(counts, hun_num-2) {
+		return true
+	}
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v] == 0 {
+				continue
+			}
+			num := counts[t][v]
+			if num > 2 {
+				num = 2
+			}
+			if need := 2 - num; need <= hun_num {
+				counts[t][v] -= num
+				ok := IsAllMelds(counts, hun_num-need)
+				counts[t][v] += num
+				if ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// 听card的张型: 手牌加上card之后, 看card在每一种拆法里面的位置.
+// 有混的时候card所在的那一组只用真牌, 混只去补别的组
+func WaitShape(cards []int32, card int32, hun_card int32) int {
+	var real_cards []int32
+	hun_num := 0
+	for _, c := range cards {
+		if hun_card != 0 && c == hun_card {
+			hun_num++
+		} else {
+			real_cards = append(real_cards, c)
+		}
+	}
+	counts := utils.NewHand(real_cards)
+	t, v := utils.Card(card).Suit(), int(utils.Card(card).Rank())
+	counts[t][v]++
+	shape := 0
+	if len(cards) == 1 {
+		shape |= area.WaitQuanQiuRen
+	}
+	// card做将
+	if counts[t][v] >= 2 {
+		counts[t][v] -= 2
+		if IsAllMelds(counts, hun_num) {
+			shape |= area.WaitDanDiao
+		}
+		counts[t][v] += 2
+	}
+	// card和手里的一对组成刻子
+	if counts[t][v] >= 3 {
+		counts[t][v] -= 3
+		if IsPairMelds(counts, hun_num) {
+			shape |= area.WaitDuiDao
+		}
+		counts[t][v] += 3
+	}
+	if !t.IsNumber() {
+		return shape
+	}
+	// card在顺子里面, 顺子从start开始
+	for start := v - 2; start <= v; start++ {
+		if start < 1 || start > 7 {
+			continue
+		}
+		if counts[t][start] == 0 || counts[t][start+1] == 0 || counts[t][start+2] == 0 {
+			continue
+		}
+		counts[t][start]--
+		counts[t][start+1]--
+		counts[t][start+2]--
+		if IsPairMelds(counts, hun_num) {
+			switch {
+			case v == start+1:
+				shape |= area.WaitKaZhang
+			case v == start && start == 7, v == start+2 && start == 1:
+				shape |= area.WaitBianZhang
+			default:
+				shape |= area.WaitShunZi
+			}
+		}
+		counts[t][start]++
+		counts[t][start+1]++
+		counts[t][start+2]++
+	}
+	return shape
+}
+
+// 给每张听的牌标上张型, 七对只能单钓
+func (m *BaseRule) SetWaitShape(player *proto.Player, result map[int32]interface{}) {
+	for card, ting := range result {
+		if card < 100 || card == player.HunCard {
+			continue
+		}
+		setter, ok := ting.(interface {
+			SetWait(shape int)
+		})
+		if !ok {
+			continue
+		}
+		if pair_7, ok := ting.(interface {
+			IsPair7() bool
+		}); ok && pair_7.IsPair7() {
+			setter.SetWait(area.WaitDanDiao)
+			continue
+		}
+		setter.SetWait(WaitShape(player.Cards, card, player.HunCard))
+	}
+}
@@ -8,12 +8,12 @@ import (
 	"server/utils"
 )
 
+// 一手牌最多四张混, 算要几张混的时候算到5就够了, 5表示怎么都凑不成
+const max_need_hun = 5
+
 type DefaultRule struct {
-	name        string
-	qin_yi_se   bool
-	jiang_yi_se bool
-	wind_yi_se  bool
-	base_rule   *base_rule.BaseRule
+	name      string
+	base_rule *base_rule.BaseRule
 }
 
 func NewDefaultRule() area.Rule {
@@ -23,10 +23,19 @@ func NewDefaultRule() area.Rule {
 }
 
 func (m *DefaultRule) IsJiang(card int32) bool {
-	if m.qin_yi_se {
+	return m.base_rule.IsJiang(card)
+}
+
+// 分析清一色的时候将牌不限258
+func (m *DefaultRule) IsJiangWithCtx(ctx *area.Context, card int32) bool {
+	if ctx.QingYiSe {
 		return true
 	}
-	return m.base_rule.IsJiang(card)
+	return m.IsJiang(card)
+}
+
+func (m *DefaultRule) MinFan() int {
+	return m.base_rule.MinFan()
 }
 
 func (m *DefaultRule) HasHun() bool {
@@ -37,6 +46,34 @@ func (m *DefaultRule) HasWind() bool {
 	return m.base_rule.HasWind()
 }
 
+func (m *DefaultRule) ValidPlayerNum(player_num int) bool {
+	return m.base_rule.ValidPlayerNum(player_num)
+}
+
+func (m *DefaultRule) WallCards(player_num int) []int32 {
+	return m.base_rule.WallCards(player_num)
+}
+
+func (m *DefaultRule) HandCardNum() int {
+	return m.base_rule.HandCardNum()
+}
+
+func (m *DefaultRule) DeadWallNum() int {
+	return m.base_rule.DeadWallNum()
+}
+
+func (m *DefaultRule) SelectHunCard(draw func() int32) (int32, int32) {
+	return m.base_rule.SelectHunCard(draw)
+}
+
+func (m *DefaultRule) HasDingQue() bool {
+	return false
+}
+
+func (m *DefaultRule) WinnerNum(player_num int) int {
+	return 1
+}
+
 func (m *DefaultRule) CanHu(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
 	card := disCard.Card
 	if player.CancelHu {
@@ -51,6 +88,11 @@ func (m *DefaultRule) CanHu(disCard utils.DisCard, player *proto.Player, req *pr
 			hu = true
 		}
 	}
+	if _, ok := player.PrewinCards[4]; ok {
+		if utils.Card(card).IsHonor() {
+			hu = true
+		}
+	}
 	if _, ok := player.PrewinCards[card]; ok {
 		hu = true
 	}
@@ -79,8 +121,7 @@ func (m *DefaultRule) CanHu(disCard utils.DisCard, player *proto.Player, req *pr
 func (m *DefaultRule) CanEat(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
 	var eats []*proto.Eat
 	card := disCard.Card
-	t := card / 100
-	if t == 4 || disCard.Card == player.HunCard {
+	if utils.Card(card).IsHonor() || disCard.Card == player.HunCard || !m.base_rule.CanEat(len(player.Pos)) {
 		return false
 	}
 
@@ -209,12 +250,21 @@ func (m *DefaultRule) CanPong(disCard utils.DisCard, player *proto.Player, req *
 	return false
 }
 
+func (m *DefaultRule) CanDrop(player *proto.Player, card int32) bool {
+	return true
+}
+
 func (m *DefaultRule) Hu(player *proto.Player, huRsp *proto.HuRsp) {
 
 }
-func (m *DefaultRule) Check2Combine(card1 int32, card2 int32) bool {
+
+func (m *DefaultRule) Score(ctx *area.Context, ting area.Ting, huRsp *proto.HuRsp) (int, []string) {
+	return m.base_rule.Score(ctx, ting, huRsp)
+}
+
+func (m *DefaultRule) Check2Combine(ctx *area.Context, card1 int32, card2 int32) bool {
 	if card1 == card2 {
-		if m.IsJiang(card1) {
+		if m.IsJiangWithCtx(ctx, card1) {
 			return true
 		} else {
 			return false
@@ -238,59 +288,59 @@ func (m *DefaultRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, need_hun
 	} else if len_sub_cards == 1 {
 		return utils.Min(hun_num+2, need_hun_count)
 	} else if len_sub_cards == 2 {
-		m, v0, v1 := sub_cards[0]/100, sub_cards[0]%10, sub_cards[1]%10
-		if m == 4 {
+		honor, v0, v1 := utils.Card(sub_cards[0]).IsHonor(), utils.Card(sub_cards[0]).Rank(), utils.Card(sub_cards[1]).Rank()
+		if honor {
 			if v0 == v1 {
 				return utils.Min(hun_num+1, need_hun_count)
 			}
 		} else if v1-v0 < 3 {
 			return utils.Min(hun_num+1, need_hun_count)
 		}
+		// 两张连不上, 各自配两张混
+		return utils.Min(hun_num+4, need_hun_count)
 	} else if len_sub_cards >= 3 {
-		t, v0 := sub_cards[0]/100, sub_cards[0]%10
+		honor, v0 := utils.Card(sub_cards[0]).IsHonor(), utils.Card(sub_cards[0]).Rank()
 
 		// 第一个和后两个一铺
 		for i := 1; i < len_sub_cards; i++ {
 			if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-3, false) >= need_hun_count {
 				break
 			}
-			v1 := sub_cards[i] % 10
+			v1 := utils.Card(sub_cards[i]).Rank()
 			// 13444   134不可能连一起
 			if v1-v0 > 1 {
 				break
 			}
 			if i+2 < len_sub_cards {
-				if sub_cards[i+2]%10 == v1 {
+				if utils.Card(sub_cards[i+2]).Rank() == v1 {
 					continue
 				}
 			}
 			if i+1 < len_sub_cards {
 				tmp1, tmp2, tmp3 := sub_cards[0], sub_cards[i], sub_cards[i+1]
 				if m.base_rule.Check3Combine(tmp1, tmp2, tmp3) {
-					tmp_cards := utils.Copy(sub_cards)
-					tmp_cards = utils.DelCard(tmp_cards, tmp1, tmp2, tmp3)
+					tmp_cards := utils.Without(sub_cards, tmp1, tmp2, tmp3)
 					need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num, need_hun_count)
 				}
 			}
 		}
 
 		// 第一个和第二个一铺
-		v1 := sub_cards[1] % 10
+		v1 := utils.Card(sub_cards[1]).Rank()
 		if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-2, false)+1 < need_hun_count {
-			if t == 4 {
+			if honor {
 				if v0 == v1 {
-					tmp_cards := utils.Copy(sub_cards[2:])
-					need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+1, need_hun_count)
+					need_hun_count = m.GetNeedHunInSub(sub_cards[2:], hun_num+1, need_hun_count)
 				}
 			} else {
 				for i := 1; i < len_sub_cards; i++ {
 					if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-2, false)+1 >= need_hun_count {
 						break
 					}
-					v1 = sub_cards[i] % 10
+					v1 = utils.Card(sub_cards[i]).Rank()
 					// 如果当前的value不等于下一个value则和下一个结合避免重复
 					if i+1 != len_sub_cards {
-						v2 := sub_cards[i+1] % 10
+						v2 := utils.Card(sub_cards[i+1]).Rank()
 						if v1 == v2 {
 							continue
 						}
@@ -298,8 +348,7 @@ func (m *DefaultRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, need_hun
 					mius := v1 - v0
 					if mius < 3 {
 						tmp1, tmp2 := sub_cards[0], sub_cards[i]
-						tmp_cards := utils.Copy(sub_cards)
-						tmp_cards = utils.DelCard(tmp_cards, tmp1, tmp2, 0)
+						tmp_cards := utils.Without(sub_cards, tmp1, tmp2)
 						need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+1, need_hun_count)
 						if mius >= 1 {
 							break
@@ -312,16 +361,14 @@ func (m *DefaultRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, need_hun
 		}
 		// 第一个自己一铺
 		if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-1, false)+2 < need_hun_count {
-			tmp_cards := utils.Copy(sub_cards[1:])
-			need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+2, need_hun_count)
+			need_hun_count = m.GetNeedHunInSub(sub_cards[1:], hun_num+2, need_hun_count)
 		}
 	}
 	return need_hun_count
 }
 
-func (m *DefaultRule) GetNeedHunInSubWithEye(cards []int32, min_need_num int32) int32 {
-	// 拷贝
-	cards_copy := utils.Copy(cards)
+func (m *DefaultRule) GetNeedHunInSubWithEye(ctx *area.Context, cards []int32, min_need_num int32) int32 {
+	cards_copy := cards
 	len_cards := len(cards_copy)
 	if len_cards == 0 {
 		return 2
@@ -331,33 +378,30 @@ func (m *DefaultRule) GetNeedHunInSubWithEye(cards []int32, min_need_num int32)
 	}
 	for i := 0; i < len_cards; i++ {
 		if i == len_cards-1 { // 如果是最后一张牌
-			tmp_cards := utils.Copy(cards_copy)
-			if m.IsJiang(cards_copy[i]) {
-				tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+1)
+			if m.IsJiangWithCtx(ctx, cards_copy[i]) {
+				tmp_cards := utils.Without(cards_copy, cards_copy[i])
+				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)+1)
 			} else {
-				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+2)
+				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(cards_copy, 0, max_need_hun)+2)
 			}
 		} else {
-			if i+2 == len_cards || cards_copy[i]%10 != cards_copy[i+2]%10 {
-				tmp_cards := utils.Copy(cards_copy)
-				if m.Check2Combine(cards_copy[i], cards_copy[i+1]) {
-					tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], cards_copy[i+1], 0)
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4))
+			if i+2 == len_cards || utils.Card(cards_copy[i]).Rank() != utils.Card(cards_copy[i+2]).Rank() {
+				if m.Check2Combine(ctx, cards_copy[i], cards_copy[i+1]) {
+					tmp_cards := utils.Without(cards_copy, cards_copy[i], cards_copy[i+1])
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun))
 				} else {
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+2)
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(cards_copy, 0, max_need_hun)+2)
 				}
 			}
-			if cards_copy[i]%10 != cards_copy[i+1]%10 {
-				tmp_cards := utils.Copy(cards_copy)
-				if m.IsJiang(tmp_cards[i]) {
-					tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+1)
-				} else if m.IsJiang(tmp_cards[i+1]) {
-					tmp_cards = utils.DelCard(tmp_cards, cards_copy[i+1], 0, 0)
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+1)
+			if utils.Card(cards_copy[i]).Rank() != utils.Card(cards_copy[i+1]).Rank() {
+				if m.IsJiangWithCtx(ctx, cards_copy[i]) {
+					tmp_cards := utils.Without(cards_copy, cards_copy[i])
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)+1)
+				} else if m.IsJiangWithCtx(ctx, cards_copy[i+1]) {
+					tmp_cards := utils.Without(cards_copy, cards_copy[i+1])
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)+1)
 				} else {
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+2)
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(cards_copy, 0, max_need_hun)+2)
 				}
 			}
 		}
@@ -388,16 +432,21 @@ func (m *DefaultRule) GetBestComb(separate_results [5][]int32, need_hun_arr []in
 			result = append(result, i)
 		}
 	}
+	// 手里除了混没有别的牌, 两张混做将
+	if len(result) == 0 && sum_num == 0 {
+		min_need_num = 2
+	}
 	return min_need_num, result
 }
 
-func (m *DefaultRule) CheckQingYiSe(player *proto.Player) map[int32]interface{} {
+func (m *DefaultRule) CheckQingYiSe(ctx *area.Context) map[int32]interface{} {
+	player := ctx.Player
 	result := make(map[int32]interface{})
 	var se_count []int32
 	separate_results := utils.SeparateCards(player.Cards, player.HunCard)
 
 	for _, wave := range player.Waves {
-		t := wave.Cards[0] / 100
+		t := int32(utils.Card(wave.Cards[0]).Suit())
 		if utils.Contain(se_count, t) {
 			continue
 		}
@@ -409,7 +458,7 @@ func (m *DefaultRule) CheckQingYiSe(player *proto.Player) map[int32]interface{}
 
 	for _, cards := range separate_results[1:] {
 		if len(cards) != 0 {
-			t := cards[0] / 100
+			t := int32(utils.Card(cards[0]).Suit())
 			if utils.Contain(se_count, t) {
 				continue
 			}
@@ -420,36 +469,27 @@ func (m *DefaultRule) CheckQingYiSe(player *proto.Player) map[int32]interface{}
 		}
 	}
 
-	m.qin_yi_se = true
+	ctx.QingYiSe = true
 	t := se_count[0]
 	cur_hun_num := int32(len(separate_results[0]))
-	need_num := m.GetNeedHunInSub(separate_results[t], 0, 4)
-	if need_num < cur_hun_num {
-		result[2] = m.NewTing(2)
-		m.qin_yi_se = false
-		return result
-	}
 	for i := int32(1); i < 10; i++ {
-		card := int32(t*100 + i)
-		if card == player.HunCard {
-			continue
-		}
-		tmp_cards := utils.Copy(separate_results[t])
-		tmp_cards = append(tmp_cards, card)
-		utils.SortCards(tmp_cards, player.HunCard)
-		if m.GetNeedHunInSubWithEye(tmp_cards, 4) <= cur_hun_num {
-			result[card] = m.NewTing(card)
+		card := int32(utils.NewCard(utils.Suit(t), i))
+		// 摸到混也可以当它本身用, 和同门的牌一起排
+		tmp_cards := utils.InsertCard(separate_results[t], card)
+		if m.GetNeedHunInSubWithEye(ctx, tmp_cards, max_need_hun) <= cur_hun_num {
+			result[card] = m.NewTing(ctx, card)
 		}
 	}
-	m.qin_yi_se = false
+	ctx.QingYiSe = false
 	return result
 }
 
-func (m *DefaultRule) NewTing(card int32) *Ting {
-	return NewTing(card, m.qin_yi_se, m.jiang_yi_se, m.wind_yi_se)
+func (m *DefaultRule) NewTing(ctx *area.Context, card int32) *Ting {
+	return NewTing(card, ctx.QingYiSe, ctx.JiangYiSe, ctx.WindYiSe)
 }
 
-func (m *DefaultRule) CheckPengPengHu(player *proto.Player, result1 map[int32]interface{}) map[int32]interface{} {
+func (m *DefaultRule) CheckPengPengHu(ctx *area.Context, result1 map[int32]interface{}) map[int32]interface{} {
+	player := ctx.Player
 	separate_results := utils.SeparateCards(player.Cards, player.HunCard)
 	for _, wave := range player.Waves {
 		if wave.WaveType == proto.Wave_EatWave {
@@ -472,29 +512,29 @@ func (m *DefaultRule) CheckPengPengHu(player *proto.Player, result1 map[int32]in
 			case 1:
 				if eye {
 					need_hun = need_hun + 2
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				} else {
 					eye = true
 					need_hun = need_hun + 1
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				}
 			case 2:
 				if eye {
 					need_hun = need_hun + 1
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				} else {
 					eye = true
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				}
 			case 3:
 			case 4:
 				if eye {
 					need_hun = need_hun + 2
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				} else {
 					eye = true
 					need_hun = need_hun + 1
-					result[card] = m.NewTing(card).SetPengPengHu()
+					result[card] = m.NewTing(ctx, card).SetPengPengHu()
 				}
 			}
 			if cur_hun_num+1 < need_hun {
@@ -503,7 +543,7 @@ func (m *DefaultRule) CheckPengPengHu(player *proto.Player, result1 map[int32]in
 		}
 	}
 	if eye && cur_hun_num > need_hun+1 || !eye && cur_hun_num > need_hun {
-		result[1] = m.NewTing(1).SetPengPengHu()
+		result[1] = m.NewTing(ctx, 1).SetPengPengHu()
 	}
 	for key, value := range result1 {
 		result[key] = value
@@ -520,8 +560,9 @@ func (m *DefaultRule) CheckJiangYiSe(player *proto.Player) bool {
 			return false
 		}
 	}
+	// 混可以当任何牌
 	for _, card := range player.Cards {
-		if !m.base_rule.IsJiang(card) {
+		if card != player.HunCard && !m.base_rule.IsJiang(card) {
 			return false
 		}
 	}
@@ -533,19 +574,21 @@ func (m *DefaultRule) CheckWindYiSe(player *proto.Player) bool {
 		if wave.WaveType == proto.Wave_EatWave {
 			return false
 		}
-		if wave.Cards[0]/100 != 4 {
+		if !utils.Card(wave.Cards[0]).IsHonor() {
 			return false
 		}
 	}
+	// 混可以当任何牌
 	for _, card := range player.Cards {
-		if card/100 != 4 {
+		if card != player.HunCard && !utils.Card(card).IsHonor() {
 			return false
 		}
 	}
 	return true
 }
 
-func (m *DefaultRule) CheckPair7(player *proto.Player, result map[int32]interface{}) map[int32]interface{} {
+func (m *DefaultRule) CheckPair7(ctx *area.Context, result map[int32]interface{}) map[int32]interface{} {
+	player := ctx.Player
 	if len(player.Cards) != 13 {
 		return result
 	}
@@ -555,7 +598,8 @@ func (m *DefaultRule) CheckPair7(player *proto.Player, result map[int32]interfac
 	cards := player.Cards
 	dan_cards := []int32{}
 	shuang_cards := []int32{}
-	cards = utils.DelCountCard(cards, player.HunCard, cur_hun_num)
+	// DelCountCard会改底层数组, 拷贝一份, 不能动ctx里面的手牌
+	cards = utils.DelCountCard(utils.Copy(cards), player.HunCard, cur_hun_num)
 	for i := 0; i < len(cards); {
 		// 最后一张牌的情况
 		if i+1 == len(cards) {
@@ -574,93 +618,85 @@ func (m *DefaultRule) CheckPair7(player *proto.Player, result map[int32]interfac
 
 	if 7-count == cur_hun_num+1 {
 		for _, card := range dan_cards {
-			ting := m.NewTing(card).SetPair7()
+			ting := m.NewTing(ctx, card).SetPair7()
 			result[card] = ting
 		}
 	}
-	if 7-count < cur_hun_num {
-		result[1] = m.NewTing(1).SetPair7()
+	// 单张都用混配完了还剩下混, 摸什么都能和混凑一对
+	if 7-count <= cur_hun_num {
+		result[1] = m.NewTing(ctx, 1).SetPair7()
 	}
 
 	return result
 }
 
-func (m *DefaultRule) GetTingCards(player *proto.Player) ([]int32, []int32, map[int32]interface{}) {
+func (m *DefaultRule) GetTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	need_hun, need_hun_with_eye, result := m.getTingCards(ctx)
+	m.base_rule.SetWaitShape(ctx.Player, result)
+	return need_hun, need_hun_with_eye, result
+}
+
+func (m *DefaultRule) getTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	player := ctx.Player
 	separate_results := utils.SeparateCards(player.Cards, player.HunCard)
-	result := m.CheckQingYiSe(player)
-	result = m.CheckPair7(player, result)
-	m.jiang_yi_se = m.CheckJiangYiSe(player)
-	m.wind_yi_se = m.CheckWindYiSe(player)
-	result = m.CheckPengPengHu(player, result)
-	if m.jiang_yi_se {
-		result[2] = m.NewTing(2).SetJiangYiSe()
-		return player.NeedHun, player.NeedHunWithEye, result
-	}
-	if m.wind_yi_se {
-		result[4] = m.NewTing(4).SetWindYiSe()
-		return player.NeedHun, player.NeedHunWithEye, result
-	}
+	result := m.CheckQingYiSe(ctx)
+	result = m.CheckPair7(ctx, result)
+	ctx.JiangYiSe = m.CheckJiangYiSe(player)
+	ctx.WindYiSe = m.CheckWindYiSe(player)
+	result = m.CheckPengPengHu(ctx, result)
+	// 将一色和风一色听所有的将牌和风牌, 带混的时候别的牌也可能凑成面子, 这些牌不算将一色和风一色, 还要接着算
+	if ctx.JiangYiSe {
+		result[2] = m.NewTing(ctx, 2).SetJiangYiSe()
+	}
+	if ctx.WindYiSe {
+		result[4] = m.NewTing(ctx, 4).SetWindYiSe()
+	}
+	ctx.JiangYiSe, ctx.WindYiSe = false, false
 	if ok := result[1]; ok != nil {
 		return player.NeedHun, player.NeedHunWithEye, result
 	}
 	cur_hun_num := int32(len(separate_results[0]))
 	for i, update_flag := range player.IsNeedUpdate {
 		if update_flag {
-			player.NeedHun[i] = m.GetNeedHunInSub(separate_results[i+1], 0, 4)
-			player.NeedHunWithEye[i] = m.GetNeedHunInSubWithEye(separate_results[i+1], 4)
+			player.NeedHun[i] = m.GetNeedHunInSub(separate_results[i+1], 0, max_need_hun)
+			player.NeedHunWithEye[i] = m.GetNeedHunInSubWithEye(ctx, separate_results[i+1], max_need_hun)
 		}
 	}
-	need_num, index := m.GetBestComb(separate_results, player.NeedHun, player.NeedHunWithEye)
+	need_num, _ := m.GetBestComb(separate_results, player.NeedHun, player.NeedHunWithEye)
 	//log.Debug("uid:%v separate_results:%v", player.Uid, separate_results)
-	//log.Debug("uid:%v need_hun_arr:%v, need_hun_with_eye_arr:%v index:%v", player.Uid, player.NeedHun, player.NeedHunWithEye, index)
+	//log.Debug("uid:%v need_hun_arr:%v, need_hun_with_eye_arr:%v", player.Uid, player.NeedHun, player.NeedHunWithEye)
 	if cur_hun_num-need_num >= 2 {
-		result[1] = m.NewTing(1)
+		result[1] = m.NewTing(ctx, 1)
 		return player.NeedHun, player.NeedHunWithEye, result
 	}
+	// 面子之外还多一个混, 什么将牌都能和它做将, 别的牌还要一张一张看
 	if cur_hun_num-m.SumNeedHun(player.NeedHun) > 0 {
 		if ok := result[2]; ok == nil {
-			result[2] = m.NewTing(2)
+			result[2] = m.NewTing(ctx, 2)
 		}
-		return player.NeedHun, player.NeedHunWithEye, result
 	}
 	if need_num > cur_hun_num+1 {
 		return player.NeedHun, player.NeedHunWithEye, result
 	}
-	var cache_index []int32
-	for _, i := range index {
-		begin, end := utils.SearchRange(separate_results[i+1])
+	// 摸一张牌只改变那一门要的混, 换掉那一门之后重新选做将的门
+	for i, cards := range separate_results[1:] {
+		if len(cards) == 0 {
+			continue
+		}
+		begin, end := utils.SearchRange(cards)
 		for card := begin; card < end+1; card++ {
-			if card == player.HunCard {
-				continue
-			}
 			if ok := result[card]; ok != nil {
 				continue
 			}
-			tmp_cards := utils.Copy(separate_results[i+1])
-			tmp_cards = append(tmp_cards, card)
-			utils.SortCards(tmp_cards, player.HunCard)
-			if m.GetNeedHunInSubWithEye(tmp_cards, 4) <= player.NeedHunWithEye[i]-1 {
-				result[card] = m.NewTing(card)
-			}
-		}
-		for j := 0; j < 4; j++ {
-			if j != i && len(separate_results[j+1]) != 0 && !utils.Contain(cache_index, int32(j)) {
-				cache_index = append(cache_index, int32(j))
-				begin, end := utils.SearchRange(separate_results[j+1])
-				for card := begin; card < end+1; card++ {
-					if card == player.HunCard {
-						continue
-					}
-					if ok := result[card]; ok != nil {
-						continue
-					}
-					tmp_cards := utils.Copy(separate_results[j+1])
-					tmp_cards = append(tmp_cards, card)
-					utils.SortCards(tmp_cards, player.HunCard)
-					if m.GetNeedHunInSub(tmp_cards, 0, 4) <= player.NeedHun[j]-1 {
-						result[card] = m.NewTing(card)
-					}
-				}
+			tmp_cards := utils.InsertCard(cards, card)
+			tmp_results := separate_results
+			tmp_results[i+1] = tmp_cards
+			need_hun := utils.Copy(player.NeedHun)
+			need_hun_with_eye := utils.Copy(player.NeedHunWithEye)
+			need_hun[i] = m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)
+			need_hun_with_eye[i] = m.GetNeedHunInSubWithEye(ctx, tmp_cards, max_need_hun)
+			if num, _ := m.GetBestComb(tmp_results, need_hun, need_hun_with_eye); num <= cur_hun_num {
+				result[card] = m.NewTing(ctx, card)
 			}
 		}
 	}
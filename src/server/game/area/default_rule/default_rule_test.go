@@ -0,0 +1,135 @@
+package default_rule
+
+import (
+	"math/rand"
+	"server/game/area"
+	"server/game/area/areatest"
+	"server/proto"
+	"server/utils"
+	"testing"
+)
+
+var ting_cases = []areatest.Case{
+	// 258做将
+	{Hand: "23m456p789s55p111z", Waits: "14m", Tings: "1m: 4m:"},
+	{Hand: "12m456p789s55p111z", Waits: "3m", Tings: "3m:BianZhang"},
+	{Hand: "13m456p789s55p111z", Waits: "2m", Tings: "2m:KaZhang"},
+	{Hand: "123m456p789s5p111z", Waits: "5p", Tings: "5p:DanDiao,KaZhang"},
+	{Hand: "23m456p789s44p111z", Waits: "", Tings: ""},
+	{Hand: "1223m456p789s55p4z", Waits: "", Tings: ""},
+	// 混
+	{Hand: "12345m56788p + hun x1", Hun: "9s", Waits: "36m9s", Tings: "3m: 6m:"},
+	{Hand: "123m456p789s11z + hun x2", Hun: "5s", Waits: "258m258p258s1z", Tings: "飘将: 2m:DanDiao,KaZhang 5m: 2p: 5p:DanDiao,KaZhang 8p: 5s: 8s:DanDiao,KaZhang 1z:DanDiao,DuiDao"},
+	{Hand: "123m456p789s1z + hun x3", Hun: "9p", Waits: "258m2589p258s1z", Tings: "飘将: 2m:DanDiao,KaZhang 5m: 2p: 5p:DanDiao,KaZhang 8p: 5s: 8s:DanDiao,KaZhang 1z:DanDiao"},
+	// 混当它本身
+	{Hand: "1255m456s444z", Hun: "3m", Waves: []string{"222z"}, Waits: "3m", Tings: "3m:"},
+	{Hand: "112344566889s + hun x1", Hun: "7s", Waits: "7s", Tings: "7s:QingYiSe"},
+	{Hand: "123m456p789s + hun x4", Hun: "1z", Waits: "123456789m123456789p123456789s234567z", Tings: "腾空:"},
+	// 清一色不限将
+	{Hand: "1112345678999m", Waits: "123456789m",
+		Tings: "1m:QingYiSe,DuiDao 2m:QingYiSe,DanDiao 3m:QingYiSe,BianZhang 4m:QingYiSe 5m:QingYiSe,DanDiao 6m:QingYiSe 7m:QingYiSe,BianZhang 8m:QingYiSe,DanDiao 9m:QingYiSe,DuiDao"},
+	// 七对
+	{Hand: "1133m5577p99s114z", Waits: "4z", Tings: "4z:Pair7,DanDiao"},
+	{Hand: "1133m5577p11z46z + hun x1", Hun: "9s", Waits: "9s46z", Tings: "4z:Pair7,DanDiao 6z:Pair7,DanDiao"},
+	// 碰碰胡不限将
+	{Hand: "111m222p333s4445z", Hun: "9s", Waits: "9s5z", Tings: "5z:PengPengHu,DanDiao"},
+	{Hand: "4p", Hun: "9s", Waves: []string{"111m", "444m", "777p", "888s"}, Waits: "4p9s", Tings: "4p:PengPengHu,QuanQiuRen,DanDiao"},
+	{Hand: "4p", Hun: "9s", Waves: []string{"123m", "444m", "777p", "888s"}, Waits: "", Tings: ""},
+	{Hand: "5p", Hun: "9s", Waves: []string{"123m", "456m", "777p", "888s"}, Waits: "5p9s", Tings: "5p:QuanQiuRen,DanDiao"},
+	// 将一色和风一色不看牌型
+	{Hand: "222555888m2225p", Hun: "1z", Waits: "258m258p258s1z", Tings: "飘将:JiangYiSe 5p:PengPengHu,JiangYiSe,DanDiao"},
+	{Hand: "5m222555p88s + hun x1", Hun: "2s", Waves: []string{"888p"}, Waits: "2345678m258p258s",
+		Tings: "飘将:JiangYiSe 3m: 4m: 5m:PengPengHu,JiangYiSe,DanDiao 6m: 7m: 8s:PengPengHu,JiangYiSe,DuiDao"},
+	{Hand: "1112223334445z", Hun: "9s", Waits: "9s1234567z", Tings: "风一色:FengYiSe 5z:QingYiSe,DanDiao"},
+}
+
+func TestTingCases(t *testing.T) {
+	rule := NewDefaultRule()
+	for _, c := range ting_cases {
+		if err := c.Check(rule); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// 参考实现里的胡牌: 258做将的四组面子一对将, 七对, 清一色(不算字牌)和碰碰胡不限将,
+// 将一色(全是将牌)和风一色(全是风)听所有的将牌和风牌, 混可以当任何牌
+func refWin(rule *DefaultRule) areatest.WinFunc {
+	return func(player *proto.Player, cards []int32, card int32) bool {
+		counts, hun_num := areatest.CountCards(cards, player.HunCard)
+		if areatest.IsStandard(counts, hun_num, rule.IsJiang) {
+			return true
+		}
+		if len(player.Waves) == 0 && areatest.IsPair7(counts, hun_num) {
+			return true
+		}
+		any := func(card int32) bool { return true }
+		suits := make(map[int32]bool)
+		eat := false
+		for _, wave := range player.Waves {
+			eat = eat || wave.WaveType == proto.Wave_EatWave
+			suits[wave.Cards[0]/100] = true
+		}
+		for _, c := range cards {
+			if c != player.HunCard {
+				suits[c/100] = true
+			}
+		}
+		if len(suits) == 1 && !suits[4] && areatest.IsStandard(counts, hun_num, any) {
+			return true
+		}
+		if eat {
+			return false
+		}
+		if areatest.IsAllPongs(counts, hun_num, any) {
+			return true
+		}
+		if card == player.HunCard {
+			card = 0
+		}
+		if rule.CheckJiangYiSe(player) && (card == 0 || rule.IsJiang(card)) {
+			return true
+		}
+		return rule.CheckWindYiSe(player) && (card == 0 || card/100 == 4)
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	rule := NewDefaultRule().(*DefaultRule)
+	kinds := areatest.CardKinds(rule)
+	hun_card := func(r *rand.Rand) int32 {
+		return kinds[r.Intn(len(kinds))]
+	}
+	if err := areatest.CrossCheck(rule, 1, 20000, hun_card, refWin(rule)); err != nil {
+		t.Error(err)
+	}
+}
+
+// 一门牌凑成面子(和将)至少要几张混, 和暴力拆牌比
+func TestGetNeedHunInSub(t *testing.T) {
+	rule := NewDefaultRule().(*DefaultRule)
+	ctx := area.NewContext(&proto.Player{}, nil)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		t_ := int32(r.Intn(4) + 1)
+		max := int32(9)
+		if t_ == 4 {
+			max = 7
+		}
+		var cards []int32
+		for num := r.Intn(12); len(cards) < num; {
+			card := t_*100 + r.Int31n(max) + 1
+			if utils.Count(cards, card) < 4 {
+				cards = append(cards, card)
+			}
+		}
+		utils.SortCards(cards, 0)
+		counts, _ := areatest.CountCards(cards, 0)
+		if got, want := rule.GetNeedHunInSub(cards, 0, max_need_hun), int32(areatest.NeedHun(counts, nil, max_need_hun)); got != want {
+			t.Errorf("GetNeedHunInSub(%v) = %v, want %v", utils.CardsNotation(cards), got, want)
+		}
+		if got, want := rule.GetNeedHunInSubWithEye(ctx, cards, max_need_hun), int32(areatest.NeedHun(counts, rule.IsJiang, max_need_hun)); got != want {
+			t.Errorf("GetNeedHunInSubWithEye(%v) = %v, want %v", utils.CardsNotation(cards), got, want)
+		}
+	}
+}
@@ -3,22 +3,17 @@ package default_rule
 import (
 	"fmt"
 	"server/game/area"
-	"server/utils"
+	"server/game/area/base_rule"
 )
 
 type Ting struct {
+	base_rule.Wait
 	rule         area.Rule
-	card         int32
 	pengpeng_hu  bool
 	pair_7       bool
 	qingyise     bool
 	jiangyise    bool
 	windyise     bool
-	dandian      bool
-	kazhang      bool
-	duidao       bool
-	shunzi       bool
-	quanqiuren   bool
 	is_ying      bool
 	dragon_num   int
 	need_hun_num int
@@ -26,7 +21,7 @@ type Ting struct {
 
 func NewTing(card int32, qingyise bool, jiangyise bool, windyise bool) *Ting {
 	ting := new(Ting)
-	ting.card = card
+	ting.Wait = base_rule.NewWait(card)
 	ting.qingyise = qingyise
 	ting.jiangyise = jiangyise
 	ting.windyise = windyise
@@ -39,21 +34,25 @@ func (m *Ting) String() string {
 
 func (m *Ting) Info() string {
 	if m.pengpeng_hu {
-		return "碰碰胡:" + fmt.Sprintf("%v", utils.CardStr(m.card))
+		return "碰碰胡:" + m.CardInfo()
 	}
 	if m.qingyise {
-		return "清一色:" + fmt.Sprintf("%v", utils.CardStr(m.card))
+		return "清一色:" + m.CardInfo()
 	}
 	if m.jiangyise {
-		return "将一色:" + fmt.Sprintf("%v", utils.CardStr(m.card))
+		return "将一色:" + m.CardInfo()
 	}
 	if m.windyise {
-		return "风一色:" + fmt.Sprintf("%v", utils.CardStr(m.card))
+		return "风一色:" + m.CardInfo()
 	}
 	if m.pair_7 {
-		return "七对:" + fmt.Sprintf("%v", utils.CardStr(m.card))
+		return "七对:" + m.CardInfo()
 	}
-	return fmt.Sprintf("%v", utils.CardStr(m.card))
+	return m.CardInfo()
+}
+
+func (m *Ting) IsPair7() bool {
+	return m.pair_7
 }
 
 func (m *Ting) SetPengPengHu() area.Ting {
@@ -78,16 +77,31 @@ func (m *Ting) SetPair7() area.Ting {
 
 func (m *Ting) Copy() *Ting {
 	ting := new(Ting)
-	ting.card = m.card
+	ting.Wait = m.Wait
 	ting.pengpeng_hu = m.pengpeng_hu
 	ting.pair_7 = m.pair_7
 	ting.qingyise = m.qingyise
-	ting.dandian = m.dandian
-	ting.kazhang = m.kazhang
-	ting.duidao = m.duidao
-	ting.shunzi = m.shunzi
-	ting.quanqiuren = m.quanqiuren
 	ting.dragon_num = m.dragon_num
 	ting.need_hun_num = m.need_hun_num
 	return ting
 }
+
+func (m *Ting) Patterns() []string {
+	var patterns []string
+	if m.pengpeng_hu {
+		patterns = append(patterns, area.PatternPengPengHu)
+	}
+	if m.qingyise {
+		patterns = append(patterns, area.PatternQingYiSe)
+	}
+	if m.jiangyise {
+		patterns = append(patterns, area.PatternJiangYiSe)
+	}
+	if m.windyise {
+		patterns = append(patterns, area.PatternFengYiSe)
+	}
+	if m.pair_7 {
+		patterns = append(patterns, area.PatternPair7)
+	}
+	return append(patterns, area.WaitPatterns(m.WaitShape())...)
+}
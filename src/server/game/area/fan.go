@@ -0,0 +1,147 @@
+package area
+
+import (
+	"server/proto"
+)
+
+// 番型id, 和gamedata/Fan.txt里面的Id对应
+const (
+	PatternJiHu         = "JiHu"
+	PatternPengPengHu   = "PengPengHu"
+	PatternQingYiSe     = "QingYiSe"
+	PatternHunYiSe      = "HunYiSe"
+	PatternZiYiSe       = "ZiYiSe"
+	PatternJiangYiSe    = "JiangYiSe"
+	PatternFengYiSe     = "FengYiSe"
+	PatternPair7        = "Pair7"
+	PatternLongPair7    = "LongPair7"
+	PatternShiSanYao    = "ShiSanYao"
+	PatternGen          = "Gen"
+	PatternMenFeng      = "MenFeng"
+	PatternQuanFeng     = "QuanFeng"
+	PatternBianZhang    = "BianZhang"
+	PatternKaZhang      = "KaZhang"
+	PatternDanDiao      = "DanDiao"
+	PatternDuiDao       = "DuiDao"
+	PatternQuanQiuRen   = "QuanQiuRen"
+	PatternZiMo         = "ZiMo"
+	PatternGangShangHua = "GangShangHua"
+	PatternQiangGangHu  = "QiangGangHu"
+	PatternHaiDiLaoYue  = "HaiDiLaoYue"
+	PatternZhuangJia    = "ZhuangJia"
+	PatternLianZhuang   = "LianZhuang"
+)
+
+var (
+	WaitPatternMap = map[int]string{WaitBianZhang: PatternBianZhang, WaitKaZhang: PatternKaZhang, WaitDanDiao: PatternDanDiao,
+		WaitDuiDao: PatternDuiDao, WaitQuanQiuRen: PatternQuanQiuRen}
+)
+
+// 番型定义
+type FanDef struct {
+	Id        string
+	Name      string
+	Value     int
+	Exclusive bool     // 独立计番, 命中之后不再叠加别的番型
+	Exclude   []string // 命中之后不再计算的番型
+}
+
+// 一个区域启用的番型表, 只读, 重新加载的时候整个替换
+type Catalog struct {
+	fans map[string]*FanDef
+}
+
+func NewCatalog(defs []*FanDef) *Catalog {
+	catalog := &Catalog{fans: make(map[string]*FanDef)}
+	for _, def := range defs {
+		catalog.fans[def.Id] = def
+	}
+	return catalog
+}
+
+func (m *Catalog) Get(id string) (*FanDef, bool) {
+	def, ok := m.fans[id]
+	return def, ok
+}
+
+// 按番型表计番, 没有启用的番型不算, 同一个番型出现几次算几次(比如根)
+func (m *Catalog) Score(patterns []string) (int, []string) {
+	var hits []*FanDef
+	var exclusive *FanDef
+	for _, id := range patterns {
+		def, ok := m.fans[id]
+		if !ok {
+			continue
+		}
+		hits = append(hits, def)
+		if def.Exclusive && (exclusive == nil || def.Value > exclusive.Value) {
+			exclusive = def
+		}
+	}
+	if exclusive != nil {
+		return exclusive.Value, []string{exclusive.Name}
+	}
+	excluded := make(map[string]bool)
+	for _, def := range hits {
+		for _, id := range def.Exclude {
+			excluded[id] = true
+		}
+	}
+	fan := 0
+	var names []string
+	for _, def := range hits {
+		if excluded[def.Id] {
+			continue
+		}
+		fan += def.Value
+		names = append(names, def.Name)
+	}
+	return fan, names
+}
+
+// 张型对应的番型
+func WaitPatterns(shape int) []string {
+	var patterns []string
+	for _, wait := range WaitShapes {
+		if id, ok := WaitPatternMap[wait]; ok && shape&wait != 0 {
+			patterns = append(patterns, id)
+		}
+	}
+	return patterns
+}
+
+// 胡牌方式对应的番型
+func HuPatterns(player *proto.Player, huRsp *proto.HuRsp) []string {
+	var patterns []string
+	switch huRsp.Type {
+	case proto.HuType_Mo:
+		patterns = append(patterns, PatternZiMo)
+	case proto.HuType_GangHua:
+		patterns = append(patterns, PatternZiMo, PatternGangShangHua)
+	case proto.HuType_QiangGang:
+		patterns = append(patterns, PatternQiangGangHu)
+	case proto.HuType_HaiDiLao:
+		patterns = append(patterns, PatternZiMo, PatternHaiDiLaoYue)
+	}
+	if player.Dealer == player.Uid {
+		patterns = append(patterns, PatternZhuangJia)
+		for i := int32(0); i < player.LianZhuang; i++ {
+			patterns = append(patterns, PatternLianZhuang)
+		}
+	}
+	return patterns
+}
+
+// 起胡番: 算上胡法之后的总番数够不够rule.MinFan(), 没有番型表的时候不限制.
+// 庄家连庄不是胡出来的番, 按闲家算, 不然庄家鸡胡能点炮别人不能
+func ReachMinFan(rule Rule, ctx *Context, ting Ting, huRsp *proto.HuRsp) bool {
+	if rule.MinFan() <= 0 || ctx.Catalog == nil {
+		return true
+	}
+	player := *ctx.Player
+	player.Dealer = player.Uid + 1
+	xian_ctx := *ctx
+	xian_ctx.Player = &player
+	fan, _ := rule.Score(&xian_ctx, ting, huRsp)
+	return fan >= rule.MinFan()
+}
@@ -0,0 +1,409 @@
+package guangdong_rule
+
+import (
+	"github.com/jxbdlut/leaf/log"
+	"server/game/area"
+	"server/game/area/base_rule"
+	"server/proto"
+	"server/utils"
+)
+
+// 起胡番: 鸡胡本身不算番, 至少还要再有一番才能胡
+const MinFan = 1
+
+var (
+	// 十三幺: 一九万, 一九饼, 一九条, 東南西北中發白
+	shisanyao_cards = []int32{101, 109, 201, 209, 301, 309, 401, 402, 403, 404, 405, 406, 407}
+)
+
+// 广东推倒胡(鸡胡): 有风有花, 没有混, 可以吃上家, 可以抢杠胡, 番数不够min_fan不能胡
+type GuangDongRule struct {
+	name      string
+	min_fan   int
+	base_rule *base_rule.BaseRule
+}
+
+func NewGuangDongRule() area.Rule {
+	rule := new(GuangDongRule)
+	rule.name = "推倒胡"
+	rule.min_fan = MinFan
+	rule.base_rule = base_rule.NewBaseRule(true, false, false)
+	return rule
+}
+
+func (m *GuangDongRule) MinFan() int {
+	return m.min_fan
+}
+
+func (m *GuangDongRule) IsJiang(card int32) bool {
+	return m.base_rule.IsJiang(card)
+}
+
+func (m *GuangDongRule) HasHun() bool {
+	return m.base_rule.HasHun()
+}
+
+func (m *GuangDongRule) HasWind() bool {
+	return m.base_rule.HasWind()
+}
+
+func (m *GuangDongRule) ValidPlayerNum(player_num int) bool {
+	return m.base_rule.ValidPlayerNum(player_num)
+}
+
+// 牌墙带上八张花牌
+func (m *GuangDongRule) WallCards(player_num int) []int32 {
+	return base_rule.BuildWall(m.base_rule.Suits(player_num), true, true, 4)
+}
+
+func (m *GuangDongRule) HandCardNum() int {
+	return m.base_rule.HandCardNum()
+}
+
+func (m *GuangDongRule) DeadWallNum() int {
+	return m.base_rule.DeadWallNum()
+}
+
+func (m *GuangDongRule) SelectHunCard(draw func() int32) (int32, int32) {
+	return m.base_rule.SelectHunCard(draw)
+}
+
+func (m *GuangDongRule) HasDingQue() bool {
+	return false
+}
+
+func (m *GuangDongRule) WinnerNum(player_num int) int {
+	return 1
+}
+
+func (m *GuangDongRule) CanHu(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	card := disCard.Card
+	if player.CancelHu {
+		return false
+	}
+	if _, ok := player.PrewinCards[card]; !ok {
+		return false
+	}
+	req.Type = req.Type | proto.OperatType_HuOperat
+	req.HuReq.Card = card
+	if disCard.FromUid == player.Uid {
+		req.HuReq.Type = proto.HuType_Mo
+		if disCard.DisType == utils.DisCard_SelfGang {
+			req.HuReq.Type = proto.HuType_GangHua
+		}
+		if disCard.DisType == utils.DisCard_HaiDi {
+			req.HuReq.Type = proto.HuType_HaiDiLao
+		}
+	} else {
+		req.HuReq.Lose = disCard.FromUid
+		if disCard.DisType == utils.DisCard_BuGang {
+			req.HuReq.Type = proto.HuType_QiangGang
+		}
+	}
+	return true
+}
+
+// 只能吃上家, 字牌不能吃, 三人不能吃
+func (m *GuangDongRule) CanEat(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	var eats []*proto.Eat
+	card := disCard.Card
+	if utils.Card(card).IsHonor() || !m.base_rule.CanEat(len(player.Pos)) {
+		return false
+	}
+
+	i, err := player.GetPlayerIndex(disCard.FromUid)
+	if err != nil {
+		log.Error("uid:%v, fromuid:%v not in table, err:%v", player.Uid, disCard.FromUid, err)
+		return false
+	}
+	j, err := player.GetPlayerIndex(player.Uid)
+	if err != nil {
+		log.Error("uid:%v, fromuid:%v not in table, err:%v", player.Uid, disCard.FromUid, err)
+		return false
+	}
+	if (i+1)%len(player.Pos) != j {
+		return false
+	}
+
+	c_1 := utils.Count(player.Cards, card-1)
+	c_2 := utils.Count(player.Cards, card-2)
+	c1 := utils.Count(player.Cards, card+1)
+	c2 := utils.Count(player.Cards, card+2)
+
+	if c_1 > 0 && c_2 > 0 {
+		eats = append(eats, &proto.Eat{HandCard: []int32{card - 2, card - 1}, WaveCard: []int32{card - 2, card - 1, card}})
+	}
+	if c_1 > 0 && c1 > 0 {
+		eats = append(eats, &proto.Eat{HandCard: []int32{card - 1, card + 1}, WaveCard: []int32{card - 1, card, card + 1}})
+	}
+	if c1 > 0 && c2 > 0 {
+		eats = append(eats, &proto.Eat{HandCard: []int32{card + 1, card + 2}, WaveCard: []int32{card, card + 1, card + 2}})
+	}
+	if len(eats) > 0 {
+		req.Type = req.Type | proto.OperatType_EatOperat
+		req.EatReq.Eat = eats
+		return true
+	}
+	return false
+}
+
+func (m *GuangDongRule) CanAnGang(player *proto.Player, req *proto.OperatReq) bool {
+	ret := false
+	record := []int32{}
+	for _, card := range player.Cards {
+		if utils.Contain(record, card) {
+			continue
+		}
+		record = append(record, card)
+		if utils.Count(player.Cards, card) < 4 {
+			continue
+		}
+		req.Type = req.Type | proto.OperatType_GangOperat
+		gang := &proto.Gang{
+			Cards: []int32{card, card, card, card},
+			Type:  proto.GangType_AnGang,
+		}
+		req.GangReq.Gang = append(req.GangReq.Gang, gang)
+		ret = true
+	}
+	return ret
+}
+
+func (m *GuangDongRule) CanBuGang(player *proto.Player, req *proto.OperatReq) bool {
+	ret := false
+	for _, wave := range player.Waves {
+		if wave.WaveType != proto.Wave_PongWave {
+			continue
+		}
+		if utils.Count(player.Cards, wave.Cards[0]) > 0 {
+			req.Type = req.Type | proto.OperatType_GangOperat
+			gang := &proto.Gang{
+				Cards: []int32{wave.Cards[0]},
+				Type:  proto.GangType_BuGang,
+			}
+			req.GangReq.Gang = append(req.GangReq.Gang, gang)
+			ret = true
+		}
+	}
+	return ret
+}
+
+func (m *GuangDongRule) CanMingGang(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	if disCard.FromUid == player.Uid {
+		return false
+	}
+	card := disCard.Card
+	if utils.Count(player.Cards, card) > 2 {
+		req.Type = req.Type | proto.OperatType_GangOperat
+		gang := &proto.Gang{
+			Cards: []int32{card, card, card},
+			Type:  proto.GangType_MingGang,
+		}
+		req.GangReq.Gang = append(req.GangReq.Gang, gang)
+		return true
+	}
+	return false
+}
+
+func (m *GuangDongRule) CanPong(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	if disCard.FromUid == player.Uid {
+		return false
+	}
+	card := disCard.Card
+	if utils.Count(player.Cards, card) > 1 {
+		req.Type = req.Type | proto.OperatType_PongOperat
+		req.PongReq.Card = card
+		return true
+	}
+	return false
+}
+
+func (m *GuangDongRule) CanDrop(player *proto.Player, card int32) bool {
+	return true
+}
+
+func (m *GuangDongRule) Hu(player *proto.Player, huRsp *proto.HuRsp) {
+
+}
+
+func (m *GuangDongRule) Score(ctx *area.Context, ting area.Ting, huRsp *proto.HuRsp) (int, []string) {
+	return m.base_rule.Score(ctx, ting, huRsp)
+}
+
+// 一门牌能否全部组成刻子或者顺子, 字牌只能组成刻子
+func (m *GuangDongRule) IsAllCombine(counts [10]int, is_wind bool) bool {
+	for v := 1; v < 10; v++ {
+		if counts[v] == 0 {
+			continue
+		}
+		if counts[v] >= 3 {
+			counts[v] -= 3
+			if m.IsAllCombine(counts, is_wind) {
+				return true
+			}
+			counts[v] += 3
+		}
+		if !is_wind && v < 8 && counts[v+1] > 0 && counts[v+2] > 0 {
+			counts[v]--
+			counts[v+1]--
+			counts[v+2]--
+			return m.IsAllCombine(counts, is_wind)
+		}
+		return false
+	}
+	return true
+}
+
+func (m *GuangDongRule) IsShiSanYao(counts utils.Hand, wave_num int) bool {
+	if wave_num != 0 {
+		return false
+	}
+	total := 0
+	for _, card := range shisanyao_cards {
+		num := counts.Count(utils.Card(card))
+		if num == 0 {
+			return false
+		}
+		total += num
+	}
+	return total == 14
+}
+
+func (m *GuangDongRule) IsPair7(counts utils.Hand, wave_num int) bool {
+	if wave_num != 0 {
+		return false
+	}
+	pairs := 0
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v]%2 != 0 {
+				return false
+			}
+			pairs += counts[t][v] / 2
+		}
+	}
+	return pairs == 7
+}
+
+func (m *GuangDongRule) IsNormalHu(counts utils.Hand) bool {
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v] < 2 {
+				continue
+			}
+			counts[t][v] -= 2
+			hu := true
+			for i := 1; i < 5; i++ {
+				if !m.IsAllCombine(counts[i], i == 4) {
+					hu = false
+					break
+				}
+			}
+			counts[t][v] += 2
+			if hu {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 碰碰胡: 没有吃, 手里除了将全部是刻子
+func (m *GuangDongRule) IsPengPengHu(counts utils.Hand, player *proto.Player) bool {
+	for _, wave := range player.Waves {
+		if wave.WaveType == proto.Wave_EatWave {
+			return false
+		}
+	}
+	pairs := 0
+	for t := 1; t < 5; t++ {
+		for v := 1; v < 10; v++ {
+			switch counts[t][v] {
+			case 0, 3:
+			case 2:
+				pairs++
+			default:
+				return false
+			}
+		}
+	}
+	return pairs == 1
+}
+
+// 门风或者圈风的刻子(包括杠)
+func (m *GuangDongRule) HasWindPong(counts utils.Hand, player *proto.Player, wind int32) bool {
+	if !utils.Card(wind).IsHonor() {
+		return false
+	}
+	for _, wave := range player.Waves {
+		if wave.WaveType != proto.Wave_EatWave && wave.Cards[0] == wind {
+			return true
+		}
+	}
+	return counts.Count(utils.Card(wind)) >= 3
+}
+
+// 统计手牌和牌墩里面出现的门, 返回万饼条的门数和是否有字牌
+func (m *GuangDongRule) Suits(player *proto.Player, card int32) (int, bool) {
+	var suits [5]bool
+	suits[utils.Card(card).Suit()] = true
+	for _, c := range player.Cards {
+		suits[utils.Card(c).Suit()] = true
+	}
+	for _, wave := range player.Waves {
+		suits[utils.Card(wave.Cards[0]).Suit()] = true
+	}
+	num := 0
+	for s := utils.SuitWan; s <= utils.SuitTiao; s++ {
+		if suits[s] {
+			num++
+		}
+	}
+	return num, suits[utils.SuitZi]
+}
+
+func (m *GuangDongRule) GetTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	player := ctx.Player
+	result := make(map[int32]interface{})
+	if !utils.IsTingCardNum(len(player.Cards)) {
+		return player.NeedHun, player.NeedHunWithEye, result
+	}
+	counts := utils.NewHand(player.Cards)
+	for s := utils.SuitWan; s <= utils.SuitZi; s++ {
+		for v := int32(1); v <= s.MaxRank(); v++ {
+			card := int32(utils.NewCard(s, v))
+			if counts[s][v] == 4 {
+				continue
+			}
+			counts[s][v]++
+			ting := NewTing(card)
+			if m.IsShiSanYao(counts, len(player.Waves)) {
+				ting.shisanyao = true
+			} else if m.IsPair7(counts, len(player.Waves)) {
+				ting.pair_7 = true
+			} else if m.IsNormalHu(counts) {
+				ting.pengpeng_hu = m.IsPengPengHu(counts, player)
+				ting.seat_wind = m.HasWindPong(counts, player, player.SeatWind)
+				ting.round_wind = m.HasWindPong(counts, player, player.RoundWind)
+			} else {
+				ting = nil
+			}
+			if ting != nil {
+				suit_num, has_wind := m.Suits(player, card)
+				ting.ziyise = suit_num == 0
+				ting.qingyise = suit_num == 1 && !has_wind
+				ting.hunyise = suit_num == 1 && has_wind
+				result[card] = ting
+			}
+			counts[s][v]--
+		}
+	}
+	m.base_rule.SetWaitShape(player, result)
+	// 张型也算番, 所以标完张型再看够不够起胡番, 按自摸算, 点炮够不够胡的时候再看
+	for card, ting := range result {
+		if !area.ReachMinFan(m, ctx, ting.(*Ting), &proto.HuRsp{Card: card, Type: proto.HuType_Mo}) {
+			delete(result, card)
+		}
+	}
+	return player.NeedHun, player.NeedHunWithEye, result
+}
@@ -0,0 +1,109 @@
+package guangdong_rule
+
+import (
+	"server/game/area"
+	"server/game/area/areatest"
+	"server/proto"
+	"server/utils"
+	"testing"
+)
+
+var ting_cases = []areatest.Case{
+	{Hand: "23m456p789s44p111z", Waits: "14m", Tings: "1m: 4m:"},
+	{Hand: "1112345678999p", Waits: "123456789p",
+		Tings: "1p:QingYiSe,DuiDao 2p:QingYiSe,DanDiao 3p:QingYiSe,BianZhang 4p:QingYiSe 5p:QingYiSe,DanDiao 6p:QingYiSe 7p:QingYiSe,BianZhang 8p:QingYiSe,DanDiao 9p:QingYiSe,DuiDao"},
+	{Hand: "111z222z123m456m7m", Waits: "147m", Tings: "1m:HunYiSe,DanDiao 4m:HunYiSe,DanDiao 7m:HunYiSe,DanDiao"},
+	{Hand: "1133m5577p99s114z", Waits: "4z", Tings: "4z:Pair7,DanDiao"},
+	{Hand: "19m19p19s1234566z", Waits: "7z", Tings: "7z:ShiSanYao"},
+	{Hand: "19m19p19s1234567z",
+		Waits: "19m19p19s1234567z",
+		Tings: "1m:ShiSanYao 9m:ShiSanYao 1p:ShiSanYao 9p:ShiSanYao 1s:ShiSanYao 9s:ShiSanYao 1z:ShiSanYao 2z:ShiSanYao 3z:ShiSanYao 4z:ShiSanYao 5z:ShiSanYao 6z:ShiSanYao 7z:ShiSanYao"},
+	// 门风圈风的刻子
+	{Hand: "23m456p789s44p111z", Wind: "1z", Waits: "14m", Tings: "1m:MenFeng,QuanFeng 4m:MenFeng,QuanFeng"},
+}
+
+func TestTingCases(t *testing.T) {
+	rule := NewGuangDongRule()
+	for _, c := range ting_cases {
+		if err := c.Check(rule); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	rule := NewGuangDongRule()
+	// 广东没有混, 四组面子一对将不限将, 七对或者十三幺
+	win := func(player *proto.Player, cards []int32, card int32) bool {
+		counts, hun_num := areatest.CountCards(cards, 0)
+		any := func(card int32) bool { return true }
+		if areatest.IsStandard(counts, hun_num, any) {
+			return true
+		}
+		return len(player.Waves) == 0 && (areatest.IsPair7(counts, hun_num) || areatest.IsShiSanYao(counts, hun_num))
+	}
+	if err := areatest.CrossCheck(rule, 1, 20000, nil, win); err != nil {
+		t.Error(err)
+	}
+}
+
+// 只有鸡胡不够起胡番, 不算听牌; 番型表里面有自摸的时候自摸够番, 点炮不够.
+// 庄家连庄不算起胡番, 庄家和闲家一样
+func TestMinFan(t *testing.T) {
+	rule := NewGuangDongRule()
+	defs := []*area.FanDef{
+		{Id: area.PatternJiHu, Name: "鸡胡"},
+		{Id: area.PatternQingYiSe, Name: "清一色", Value: 6},
+		{Id: area.PatternMenFeng, Name: "门风", Value: 1},
+		{Id: area.PatternQuanFeng, Name: "圈风", Value: 1},
+		{Id: area.PatternZhuangJia, Name: "庄家", Value: 1},
+		{Id: area.PatternLianZhuang, Name: "连庄", Value: 1},
+	}
+	zimo := &area.FanDef{Id: area.PatternZiMo, Name: "自摸", Value: 1}
+	cases := []struct {
+		hand    string
+		wind    string
+		zimo    bool
+		dealer  bool
+		waits   string
+		dianpao string
+	}{
+		{"23m456p789s44p111z", "", false, false, "", ""},
+		{"23m456p789s44p111z", "1z", false, false, "14m", "14m"},
+		{"23m456p789s44p111z", "", true, false, "14m", ""},
+		{"1112345678999p", "", false, false, "123456789p", "123456789p"},
+		{"23m456p789s44p111z", "", false, true, "", ""},
+		{"23m456p789s44p111z", "", true, true, "14m", ""},
+	}
+	for _, c := range cases {
+		catalog := area.NewCatalog(defs)
+		if c.zimo {
+			catalog = area.NewCatalog(append(defs, zimo))
+		}
+		player, err := (&areatest.Case{Hand: c.hand, Wind: c.wind}).Player()
+		if err != nil {
+			t.Fatal(err)
+		}
+		player.Uid = 1
+		if c.dealer {
+			player.Dealer, player.LianZhuang = player.Uid, 1
+		}
+		ctx := area.NewContext(player, catalog)
+		_, _, result := rule.GetTingCards(ctx)
+		var waits, dianpao []int32
+		for card, ting := range result {
+			waits = append(waits, card)
+			if area.ReachMinFan(rule, ctx, ting.(area.Ting), &proto.HuRsp{Card: card, Type: proto.HuType_Nomal}) {
+				dianpao = append(dianpao, card)
+			}
+		}
+		utils.SortCards(waits, 0)
+		utils.SortCards(dianpao, 0)
+		if got := utils.CardsNotation(waits); got != c.waits {
+			t.Errorf("%v wind:%q zimo:%v dealer:%v, waits %q, want %q", c.hand, c.wind, c.zimo, c.dealer, got, c.waits)
+		}
+		if got := utils.CardsNotation(dianpao); got != c.dianpao {
+			t.Errorf("%v wind:%q zimo:%v dealer:%v, dianpao %q, want %q", c.hand, c.wind, c.zimo, c.dealer, got, c.dianpao)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package guangdong_rule
+
+import (
+	"fmt"
+	"server/game/area"
+	"server/game/area/base_rule"
+	"strings"
+)
+
+type Ting struct {
+	base_rule.Wait
+	shisanyao   bool
+	pair_7      bool
+	pengpeng_hu bool
+	hunyise     bool
+	qingyise    bool
+	ziyise      bool
+	seat_wind   bool
+	round_wind  bool
+}
+
+func NewTing(card int32) *Ting {
+	ting := new(Ting)
+	ting.Wait = base_rule.NewWait(card)
+	return ting
+}
+
+func (m *Ting) String() string {
+	return fmt.Sprintf("%v", m.Card())
+}
+
+// 番数按番型表算, 这里只是给日志看的名字
+func (m *Ting) Names() []string {
+	if m.shisanyao {
+		return []string{"十三幺"}
+	}
+	var names []string
+	if m.ziyise {
+		names = append(names, "字一色")
+	} else if m.qingyise {
+		names = append(names, "清一色")
+	} else if m.hunyise {
+		names = append(names, "混一色")
+	}
+	if m.pair_7 {
+		names = append(names, "七对")
+	}
+	if m.pengpeng_hu {
+		names = append(names, "碰碰胡")
+	}
+	if m.seat_wind {
+		names = append(names, "门风")
+	}
+	if m.round_wind {
+		names = append(names, "圈风")
+	}
+	if len(names) == 0 {
+		names = append(names, "鸡胡")
+	}
+	return names
+}
+
+func (m *Ting) Info() string {
+	return strings.Join(m.Names(), "") + ":" + m.CardInfo()
+}
+
+func (m *Ting) IsPair7() bool {
+	return m.pair_7
+}
+
+func (m *Ting) Patterns() []string {
+	if m.shisanyao {
+		return []string{area.PatternShiSanYao}
+	}
+	var patterns []string
+	if m.ziyise {
+		patterns = append(patterns, area.PatternZiYiSe)
+	} else if m.qingyise {
+		patterns = append(patterns, area.PatternQingYiSe)
+	} else if m.hunyise {
+		patterns = append(patterns, area.PatternHunYiSe)
+	}
+	if m.pair_7 {
+		patterns = append(patterns, area.PatternPair7)
+	}
+	if m.pengpeng_hu {
+		patterns = append(patterns, area.PatternPengPengHu)
+	}
+	if m.seat_wind {
+		patterns = append(patterns, area.PatternMenFeng)
+	}
+	if m.round_wind {
+		patterns = append(patterns, area.PatternQuanFeng)
+	}
+	return append(patterns, area.WaitPatterns(m.WaitShape())...)
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/jxbdlut/leaf/log"
 )
 
+// 一手牌最多四张混, 算要几张混的时候算到5就够了, 5表示怎么都凑不成
+const max_need_hun = 5
+
 type HongZhongLaiZiRule struct {
 	name          string
 	base_rule     *base_rule.BaseRule
@@ -15,6 +18,7 @@ type HongZhongLaiZiRule struct {
 
 func NewHongZhongLaiZiRule() area.Rule {
 	rule := new(HongZhongLaiZiRule)
+	rule.name = "红中癞子"
 	rule.base_rule = base_rule.NewBaseRule(true, true, true)
 	return rule
 }
@@ -23,6 +27,10 @@ func (m *HongZhongLaiZiRule) IsJiang(card int32) bool {
 	return m.base_rule.IsJiang(card)
 }
 
+func (m *HongZhongLaiZiRule) MinFan() int {
+	return m.base_rule.MinFan()
+}
+
 func (m *HongZhongLaiZiRule) HasHun() bool {
 	return m.base_rule.HasHun()
 }
@@ -31,6 +39,36 @@ func (m *HongZhongLaiZiRule) HasWind() bool {
 	return m.base_rule.HasWind()
 }
 
+func (m *HongZhongLaiZiRule) ValidPlayerNum(player_num int) bool {
+	return m.base_rule.ValidPlayerNum(player_num)
+}
+
+// 牌墙只有数牌加上四张红中
+func (m *HongZhongLaiZiRule) WallCards(player_num int) []int32 {
+	return append(base_rule.BuildWall(m.base_rule.Suits(player_num), false, false, 4), 405, 405, 405, 405)
+}
+
+func (m *HongZhongLaiZiRule) HandCardNum() int {
+	return m.base_rule.HandCardNum()
+}
+
+func (m *HongZhongLaiZiRule) DeadWallNum() int {
+	return m.base_rule.DeadWallNum()
+}
+
+// 红中固定是癞子, 不用翻牌
+func (m *HongZhongLaiZiRule) SelectHunCard(draw func() int32) (int32, int32) {
+	return 0, 405
+}
+
+func (m *HongZhongLaiZiRule) HasDingQue() bool {
+	return false
+}
+
+func (m *HongZhongLaiZiRule) WinnerNum(player_num int) int {
+	return 1
+}
+
 
 func (m *HongZhongLaiZiRule) CanHu(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
 	card := disCard.Card
@@ -74,8 +112,7 @@ func (m *HongZhongLaiZiRule) CanHu(disCard utils.DisCard, player *proto.Player,
 func (m *HongZhongLaiZiRule) CanEat(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
 	var eats []*proto.Eat
 	card := disCard.Card
-	t := card / 100
-	if t == 4 || disCard.Card == player.HunCard {
+	if utils.Card(card).IsHonor() || disCard.Card == player.HunCard || !m.base_rule.CanEat(len(player.Pos)) {
 		return false
 	}
 
@@ -204,10 +241,18 @@ func (m *HongZhongLaiZiRule) CanPong(disCard utils.DisCard, player *proto.Player
 	return false
 }
 
+func (m *HongZhongLaiZiRule) CanDrop(player *proto.Player, card int32) bool {
+	return true
+}
+
 func (m *HongZhongLaiZiRule) Hu(player *proto.Player, huRsp *proto.HuRsp) {
 
 }
 
+func (m *HongZhongLaiZiRule) Score(ctx *area.Context, ting area.Ting, huRsp *proto.HuRsp) (int, []string) {
+	return m.base_rule.Score(ctx, ting, huRsp)
+}
+
 func (m *HongZhongLaiZiRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, need_hun_count int32) int32 {
 	if need_hun_count == 0 {
 		return need_hun_count
@@ -223,59 +268,59 @@ func (m *HongZhongLaiZiRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, n
 	} else if len_sub_cards == 1 {
 		return utils.Min(hun_num+2, need_hun_count)
 	} else if len_sub_cards == 2 {
-		m, v0, v1 := sub_cards[0]/100, sub_cards[0]%10, sub_cards[1]%10
-		if m == 4 {
+		honor, v0, v1 := utils.Card(sub_cards[0]).IsHonor(), utils.Card(sub_cards[0]).Rank(), utils.Card(sub_cards[1]).Rank()
+		if honor {
 			if v0 == v1 {
 				return utils.Min(hun_num+1, need_hun_count)
 			}
 		} else if v1-v0 < 3 {
 			return utils.Min(hun_num+1, need_hun_count)
 		}
+		// 两张连不上, 各自配两张混
+		return utils.Min(hun_num+4, need_hun_count)
 	} else if len_sub_cards >= 3 {
-		t, v0 := sub_cards[0]/100, sub_cards[0]%10
+		honor, v0 := utils.Card(sub_cards[0]).IsHonor(), utils.Card(sub_cards[0]).Rank()
 
 		// 第一个和后两个一铺
 		for i := 1; i < len_sub_cards; i++ {
 			if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-3, false) >= need_hun_count {
 				break
 			}
-			v1 := sub_cards[i] % 10
+			v1 := utils.Card(sub_cards[i]).Rank()
 			// 13444   134不可能连一起
 			if v1-v0 > 1 {
 				break
 			}
 			if i+2 < len_sub_cards {
-				if sub_cards[i+2]%10 == v1 {
+				if utils.Card(sub_cards[i+2]).Rank() == v1 {
 					continue
 				}
 			}
 			if i+1 < len_sub_cards {
 				tmp1, tmp2, tmp3 := sub_cards[0], sub_cards[i], sub_cards[i+1]
 				if m.base_rule.Check3Combine(tmp1, tmp2, tmp3) {
-					tmp_cards := utils.Copy(sub_cards)
-					tmp_cards = utils.DelCard(tmp_cards, tmp1, tmp2, tmp3)
+					tmp_cards := utils.Without(sub_cards, tmp1, tmp2, tmp3)
 					need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num, need_hun_count)
 				}
 			}
 		}
 
 		// 第一个和第二个一铺
-		v1 := sub_cards[1] % 10
+		v1 := utils.Card(sub_cards[1]).Rank()
 		if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-2, false)+1 < need_hun_count {
-			if t == 4 {
+			if honor {
 				if v0 == v1 {
-					tmp_cards := utils.Copy(sub_cards[2:])
-					need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+1, need_hun_count)
+					need_hun_count = m.GetNeedHunInSub(sub_cards[2:], hun_num+1, need_hun_count)
 				}
 			} else {
 				for i := 1; i < len_sub_cards; i++ {
 					if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-2, false)+1 >= need_hun_count {
 						break
 					}
-					v1 = sub_cards[i] % 10
+					v1 = utils.Card(sub_cards[i]).Rank()
 					// 如果当前的value不等于下一个value则和下一个结合避免重复
 					if i+1 != len_sub_cards {
-						v2 := sub_cards[i+1] % 10
+						v2 := utils.Card(sub_cards[i+1]).Rank()
 						if v1 == v2 {
 							continue
 						}
@@ -283,8 +328,7 @@ func (m *HongZhongLaiZiRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, n
 					mius := v1 - v0
 					if mius < 3 {
 						tmp1, tmp2 := sub_cards[0], sub_cards[i]
-						tmp_cards := utils.Copy(sub_cards)
-						tmp_cards = utils.DelCard(tmp_cards, tmp1, tmp2, 0)
+						tmp_cards := utils.Without(sub_cards, tmp1, tmp2)
 						need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+1, need_hun_count)
 						if mius >= 1 {
 							break
@@ -297,16 +341,14 @@ func (m *HongZhongLaiZiRule) GetNeedHunInSub(sub_cards []int32, hun_num int32, n
 		}
 		// 第一个自己一铺
 		if hun_num+m.base_rule.GetModNeedNum(len_sub_cards-1, false)+2 < need_hun_count {
-			tmp_cards := utils.Copy(sub_cards[1:])
-			need_hun_count = m.GetNeedHunInSub(tmp_cards, hun_num+2, need_hun_count)
+			need_hun_count = m.GetNeedHunInSub(sub_cards[1:], hun_num+2, need_hun_count)
 		}
 	}
 	return need_hun_count
 }
 
 func (m *HongZhongLaiZiRule) GetNeedHunInSubWithEye(cards []int32, min_need_num int32) int32 {
-	// 拷贝
-	cards_copy := utils.Copy(cards)
+	cards_copy := cards
 	len_cards := len(cards_copy)
 	if len_cards == 0 {
 		return 2
@@ -316,21 +358,26 @@ func (m *HongZhongLaiZiRule) GetNeedHunInSubWithEye(cards []int32, min_need_num
 	}
 	for i := 0; i < len_cards; i++ {
 		if i == len_cards-1 { // 如果是最后一张牌
-			tmp_cards := utils.Copy(cards_copy)
-			tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-			min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+1)
+			if m.IsJiang(cards_copy[i]) {
+				tmp_cards := utils.Without(cards_copy, cards_copy[i])
+				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)+1)
+			} else {
+				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(cards_copy, 0, max_need_hun)+2)
+			}
 		} else {
-			if i+2 == len_cards || cards_copy[i]%10 != cards_copy[i+2]%10 {
+			if i+2 == len_cards || utils.Card(cards_copy[i]).Rank() != utils.Card(cards_copy[i+2]).Rank() {
 				if m.base_rule.Check2Combine(cards_copy[i], cards_copy[i+1]) {
-					tmp_cards := utils.Copy(cards_copy)
-					tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], cards_copy[i+1], 0)
-					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4))
+					tmp_cards := utils.Without(cards_copy, cards_copy[i], cards_copy[i+1])
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun))
 				}
 			}
-			if cards_copy[i]%10 != cards_copy[i+1]%10 {
-				tmp_cards := utils.Copy(cards_copy)
-				tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-				min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, 4)+1)
+			if utils.Card(cards_copy[i]).Rank() != utils.Card(cards_copy[i+1]).Rank() {
+				if m.IsJiang(cards_copy[i]) {
+					tmp_cards := utils.Without(cards_copy, cards_copy[i])
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)+1)
+				} else {
+					min_need_num = utils.Min(min_need_num, m.GetNeedHunInSub(cards_copy, 0, max_need_hun)+2)
+				}
 			}
 		}
 	}
@@ -360,111 +407,97 @@ func (m *HongZhongLaiZiRule) GetBestComb(separate_results [5][]int32, need_hun_a
 			result = append(result, i)
 		}
 	}
+	// 手里除了混没有别的牌, 两张混做将
+	if len(result) == 0 && sum_num == 0 {
+		min_need_num = 2
+	}
 	return min_need_num, result
 }
 
-func (m *HongZhongLaiZiRule) IsHu(cards []int32, hun_num int32, hun_card int32) bool {
-	cards_copy := utils.Copy(cards)
-	len_cards := len(cards_copy)
-	if len_cards == 0 {
-		if hun_num >= 2 {
-			return true
-		} else {
-			return false
-		}
+func (m *HongZhongLaiZiRule) IsHu(cards []int32, hun_num int32) bool {
+	if len(cards) == 0 {
+		return hun_num >= 2
 	}
-
-	if hun_num < m.base_rule.GetModNeedNum(len_cards, true) {
+	if hun_num < m.base_rule.GetModNeedNum(len(cards), true) {
 		return false
 	}
-	utils.SortCards(cards_copy, hun_card)
-	for i := 0; i < len_cards; i++ {
-		// 如果是最后一张牌
-		if i+1 == len_cards {
-			if hun_num > 0 {
-				tmp_cards := utils.Copy(cards_copy)
-				tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-				if m.GetNeedHunInSub(tmp_cards, 0, 4) <= hun_num-1 {
-					return true
-				}
+	counts := utils.NewHand(cards)
+	sorted := counts.Cards()
+	// 两张混做将
+	if hun_num >= 2 && m.GetNeedHunInSub(sorted, 0, max_need_hun) <= hun_num-2 {
+		return true
+	}
+	// 每种将牌试一次做将: 一对, 或者一张加一个混
+	for s := utils.SuitWan; s <= utils.SuitZi; s++ {
+		for v := int32(1); v <= s.MaxRank(); v++ {
+			card := int32(utils.NewCard(s, v))
+			num := counts.Count(utils.Card(card))
+			if num == 0 || !m.IsJiang(card) {
+				continue
 			}
-		} else {
-			if i+2 == len_cards || cards_copy[i]%10 != cards_copy[i+2]%10 {
-				if m.base_rule.Check2Combine(cards_copy[i], cards_copy[i+1]) {
-					tmp_cards := utils.Copy(cards_copy)
-					tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], cards_copy[i+1], 0)
-					if m.GetNeedHunInSub(tmp_cards, 0, 4) <= hun_num {
-						return true
-					}
-				}
+			if num >= 2 && m.GetNeedHunInSub(utils.Without(sorted, card, card), 0, max_need_hun) <= hun_num {
+				return true
 			}
-			if hun_num > 0 && cards_copy[i]%10 != cards_copy[i+1]%10 {
-				tmp_cards := utils.Copy(cards_copy)
-				tmp_cards = utils.DelCard(tmp_cards, cards_copy[i], 0, 0)
-				if m.GetNeedHunInSub(tmp_cards, 0, 4) <= hun_num-1 {
-					return true
-				}
+			if hun_num > 0 && m.GetNeedHunInSub(utils.Without(sorted, card), 0, max_need_hun) <= hun_num-1 {
+				return true
 			}
 		}
 	}
 	return false
 }
 
-func (m *HongZhongLaiZiRule) GetTingCards(player *proto.Player) ([]int32, []int32, map[int32]interface{}) {
+func (m *HongZhongLaiZiRule) GetTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	need_hun, need_hun_with_eye, result := m.getTingCards(ctx)
+	m.base_rule.SetWaitShape(ctx.Player, result)
+	return need_hun, need_hun_with_eye, result
+}
+
+func (m *HongZhongLaiZiRule) getTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	player := ctx.Player
 	result := make(map[int32]interface{})
 	separate_results := utils.SeparateCards(player.Cards, player.HunCard)
 	var need_hun_arr []int32          // 每个分类需要混的数组
 	var need_hun_with_eye_arr []int32 // 每个将分类需要混的数组
 	cur_hun_num := int32(len(separate_results[0]))
 	for _, cards := range separate_results[1:] {
-		need_hun_arr = append(need_hun_arr, m.GetNeedHunInSub(cards, 0, 4))
-		need_hun_with_eye_arr = append(need_hun_with_eye_arr, m.GetNeedHunInSubWithEye(cards, 4))
+		need_hun_arr = append(need_hun_arr, m.GetNeedHunInSub(cards, 0, max_need_hun))
+		need_hun_with_eye_arr = append(need_hun_with_eye_arr, m.GetNeedHunInSubWithEye(cards, max_need_hun))
 	}
-	need_num, index := m.GetBestComb(separate_results, need_hun_arr, need_hun_with_eye_arr)
+	need_num, _ := m.GetBestComb(separate_results, need_hun_arr, need_hun_with_eye_arr)
 	if cur_hun_num-need_num >= 2 {
 		result[1] = NewTing(1)
 		return need_hun_arr, need_hun_with_eye_arr, result
 	}
+	// 面子之外还多一个混, 什么将牌都能和它做将, 别的牌还要一张一张看
 	if cur_hun_num-m.SumNeedHun(need_hun_arr) > 0 {
 		result[2] = NewTing(2)
-		return need_hun_arr, need_hun_with_eye_arr, result
 	}
 	if need_num > cur_hun_num+1 {
 		return need_hun_arr, need_hun_with_eye_arr, result
 	}
 	log.Debug("uid:%v separate_results:%v", player.Uid, separate_results)
-	log.Debug("uid:%v need_hun_arr:%v, need_hun_with_eye_arr:%v index:%v", player.Uid, need_hun_arr, need_hun_with_eye_arr, index)
-	var cache_index []int32
-	for _, i := range index {
-		begin, end := utils.SearchRange(separate_results[i+1])
+	log.Debug("uid:%v need_hun_arr:%v, need_hun_with_eye_arr:%v", player.Uid, need_hun_arr, need_hun_with_eye_arr)
+	// 摸一张牌只改变那一门要的混, 换掉那一门之后重新选做将的门
+	for i, cards := range separate_results[1:] {
+		if len(cards) == 0 {
+			continue
+		}
+		begin, end := utils.SearchRange(cards)
 		for card := begin; card < end+1; card++ {
 			if ok := result[card]; ok != nil {
 				continue
 			}
-			tmp_cards := utils.Copy(separate_results[i+1])
-			tmp_cards = append(tmp_cards, card)
-			utils.SortCards(tmp_cards, player.HunCard)
-			if m.IsHu(tmp_cards, need_hun_with_eye_arr[i]-1, player.HunCard) {
+			tmp_cards := utils.InsertCard(cards, card)
+			tmp_results := separate_results
+			tmp_results[i+1] = tmp_cards
+			tmp_need_hun := utils.Copy(need_hun_arr)
+			tmp_need_hun_with_eye := utils.Copy(need_hun_with_eye_arr)
+			tmp_need_hun[i] = m.GetNeedHunInSub(tmp_cards, 0, max_need_hun)
+			tmp_need_hun_with_eye[i] = m.GetNeedHunInSubWithEye(tmp_cards, max_need_hun)
+			if num, _ := m.GetBestComb(tmp_results, tmp_need_hun, tmp_need_hun_with_eye); num <= cur_hun_num {
 				result[card] = NewTing(card)
 			}
 		}
-		for j := 0; j < 4; j++ {
-			if j != i && len(separate_results[j+1]) != 0 && !utils.Contain(cache_index, int32(j)) {
-				cache_index = append(cache_index, int32(j))
-				begin, end := utils.SearchRange(separate_results[j+1])
-				for card := begin; card < end+1; card++ {
-					if ok := result[card]; ok != nil {
-						continue
-					}
-					tmp_cards := utils.Copy(separate_results[j+1])
-					tmp_cards = append(tmp_cards, card)
-					utils.SortCards(tmp_cards, player.HunCard)
-					if m.GetNeedHunInSub(tmp_cards, 0, 4) <= need_hun_arr[j]-1 {
-						result[card] = NewTing(card)
-					}
-				}
-			}
-		}
 	}
 	return need_hun_arr, need_hun_with_eye_arr, result
 }
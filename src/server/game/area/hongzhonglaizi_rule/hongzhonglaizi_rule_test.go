@@ -0,0 +1,70 @@
+package hongzhonglaizi_rule
+
+import (
+	"math/rand"
+	"server/game/area/areatest"
+	"server/proto"
+	"server/utils"
+	"testing"
+)
+
+var ting_cases = []areatest.Case{
+	{Hand: "23m456p789s55p111s", Hun: "5z", Waits: "14m5z", Tings: "1m: 4m:"},
+	{Hand: "23m456p789s44p111s", Hun: "5z", Waits: "", Tings: ""},
+	{Hand: "1112345678999m", Hun: "5z", Waits: "258m5z", Tings: "2m:DanDiao 5m:DanDiao 8m:DanDiao"},
+	// 混做将也要配258
+	{Hand: "123m456p789s11m + hun x2", Hun: "5z", Waits: "123458m258p258s5z", Tings: "飘将: 1m:DanDiao,DuiDao 2m:DanDiao,KaZhang 3m:DanDiao,BianZhang 4m: 5m: 2p: 5p:DanDiao,KaZhang 8p: 5s: 8s:DanDiao,KaZhang"},
+	{Hand: "123m456p789s1s + hun x3", Hun: "5z", Waits: "258m258p12358s5z", Tings: "飘将: 2m:DanDiao,KaZhang 5m: 2p: 5p:DanDiao,KaZhang 8p: 1s:DanDiao 2s: 3s: 5s: 8s:DanDiao,KaZhang"},
+	{Hand: "111m222p333s444p + hun x1", Hun: "5z", Waits: "258m123458p258s5z", Tings: "飘将: 2m: 1p: 2p:DanDiao,DuiDao 3p:KaZhang 4p:DanDiao,DuiDao 5p: 2s: 5s:"},
+	{Hand: "123m456p789s + hun x4", Hun: "5z", Waits: "123456789m123456789p123456789s", Tings: "腾空:"},
+	{Hand: "5555z", Hun: "5z", Waves: []string{"345m", "678m", "888p"}, Waits: "123456789m123456789p123456789s", Tings: "腾空:"},
+}
+
+func TestTingCases(t *testing.T) {
+	rule := NewHongZhongLaiZiRule()
+	for _, c := range ting_cases {
+		if err := c.Check(rule); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	rule := NewHongZhongLaiZiRule().(*HongZhongLaiZiRule)
+	hun_card := func(r *rand.Rand) int32 {
+		return 405
+	}
+	// 红中癞子只有258做将的四组面子一对将
+	win := func(player *proto.Player, cards []int32, card int32) bool {
+		counts, hun_num := areatest.CountCards(cards, player.HunCard)
+		return areatest.IsStandard(counts, hun_num, rule.IsJiang)
+	}
+	if err := areatest.CrossCheck(rule, 1, 20000, hun_card, win); err != nil {
+		t.Error(err)
+	}
+}
+
+// 一门牌带上混能不能胡, 和暴力拆牌比
+func TestIsHu(t *testing.T) {
+	rule := NewHongZhongLaiZiRule().(*HongZhongLaiZiRule)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		t_ := int32(r.Intn(3) + 1)
+		var cards []int32
+		for num := r.Intn(12); len(cards) < num; {
+			card := t_*100 + r.Int31n(9) + 1
+			if utils.Count(cards, card) < 4 {
+				cards = append(cards, card)
+			}
+		}
+		utils.SortCards(cards, 0)
+		counts, _ := areatest.CountCards(cards, 0)
+		if got, want := rule.GetNeedHunInSubWithEye(cards, max_need_hun), int32(areatest.NeedHun(counts, rule.IsJiang, max_need_hun)); got != want {
+			t.Errorf("GetNeedHunInSubWithEye(%v) = %v, want %v", utils.CardsNotation(cards), got, want)
+		}
+		hun_num := 5 - len(cards)%3
+		if got, want := rule.IsHu(cards, int32(hun_num)), areatest.IsStandard(counts, hun_num, rule.IsJiang); got != want {
+			t.Errorf("IsHu(%v + hun x%v) = %v, want %v", utils.CardsNotation(cards), hun_num, got, want)
+		}
+	}
+}
@@ -3,23 +3,18 @@ package hongzhonglaizi_rule
 import (
 	"fmt"
 	"server/game/area"
-	"server/utils"
+	"server/game/area/base_rule"
 )
 
 type Ting struct {
+	base_rule.Wait
 	rule         area.Rule
-	card         int32
 	shunzi_count int
 	kezi_count   int
 	pengpeng_hu  bool
 	pair_7       bool
 	qingyise     bool
 	jiangyise    bool
-	dandian      bool
-	kazhang      bool
-	duidao       bool
-	shunzi       bool
-	quanqiuren   bool
 	piaomen      bool
 	dragon_num   int
 	need_hun_num int
@@ -27,7 +22,7 @@ type Ting struct {
 
 func NewTing(card int32) area.Ting {
 	ting := new(Ting)
-	ting.card = card
+	ting.Wait = base_rule.NewWait(card)
 	return ting
 }
 
@@ -36,23 +31,35 @@ func (m *Ting) String() string {
 }
 
 func (m *Ting) Info() string {
-	return fmt.Sprintf("%v", utils.CardStr(m.card))
+	return m.CardInfo()
 }
 
 func (m *Ting) Copy() *Ting {
 	ting := new(Ting)
-	ting.card = m.card
+	ting.Wait = m.Wait
 	ting.pengpeng_hu = m.pengpeng_hu
 	ting.shunzi_count = m.shunzi_count
 	ting.kezi_count = m.kezi_count
 	ting.pair_7 = m.pair_7
 	ting.qingyise = m.qingyise
-	ting.dandian = m.dandian
-	ting.kazhang = m.kazhang
-	ting.duidao = m.duidao
-	ting.shunzi = m.shunzi
-	ting.quanqiuren = m.quanqiuren
 	ting.dragon_num = m.dragon_num
 	ting.need_hun_num = m.need_hun_num
 	return ting
 }
+
+func (m *Ting) Patterns() []string {
+	var patterns []string
+	if m.pengpeng_hu {
+		patterns = append(patterns, area.PatternPengPengHu)
+	}
+	if m.qingyise {
+		patterns = append(patterns, area.PatternQingYiSe)
+	}
+	if m.jiangyise {
+		patterns = append(patterns, area.PatternJiangYiSe)
+	}
+	if m.pair_7 {
+		patterns = append(patterns, area.PatternPair7)
+	}
+	return append(patterns, area.WaitPatterns(m.WaitShape())...)
+}
@@ -0,0 +1,76 @@
+package xuezhan_rule
+
+import (
+	"fmt"
+	"server/game/area"
+	"server/game/area/base_rule"
+	"strings"
+)
+
+type Ting struct {
+	base_rule.Wait
+	pengpeng_hu bool
+	pair_7      bool
+	qingyise    bool
+	gen         int
+}
+
+func NewTing(card int32) *Ting {
+	ting := new(Ting)
+	ting.Wait = base_rule.NewWait(card)
+	return ting
+}
+
+func (m *Ting) String() string {
+	return fmt.Sprintf("%v", m.Card())
+}
+
+func (m *Ting) Info() string {
+	var names []string
+	if m.qingyise {
+		names = append(names, "清一色")
+	}
+	if m.pair_7 {
+		if m.gen > 0 {
+			names = append(names, "龙七对")
+		} else {
+			names = append(names, "七对")
+		}
+	}
+	if m.pengpeng_hu {
+		names = append(names, "碰碰胡")
+	}
+	if m.gen > 0 && !m.pair_7 {
+		names = append(names, fmt.Sprintf("%v根", m.gen))
+	}
+	if len(names) == 0 {
+		return m.CardInfo()
+	}
+	return strings.Join(names, "") + ":" + m.CardInfo()
+}
+
+func (m *Ting) IsPair7() bool {
+	return m.pair_7
+}
+
+// 龙七对已经算了一根
+func (m *Ting) Patterns() []string {
+	var patterns []string
+	gen := m.gen
+	if m.qingyise {
+		patterns = append(patterns, area.PatternQingYiSe)
+	}
+	if m.pair_7 && gen > 0 {
+		patterns = append(patterns, area.PatternLongPair7)
+		gen--
+	} else if m.pair_7 {
+		patterns = append(patterns, area.PatternPair7)
+	}
+	if m.pengpeng_hu {
+		patterns = append(patterns, area.PatternPengPengHu)
+	}
+	for i := 0; i < gen; i++ {
+		patterns = append(patterns, area.PatternGen)
+	}
+	return append(patterns, area.WaitPatterns(m.WaitShape())...)
+}
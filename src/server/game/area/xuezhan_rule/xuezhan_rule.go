@@ -0,0 +1,349 @@
+package xuezhan_rule
+
+import (
+	"server/game/area"
+	"server/game/area/base_rule"
+	"server/proto"
+	"server/utils"
+)
+
+// 四川血战到底: 只有万饼条, 不能吃, 开局定缺, 一家胡牌后其余玩家继续, 直到三家胡牌或者流局
+type XueZhanRule struct {
+	name      string
+	base_rule *base_rule.BaseRule
+}
+
+func NewXueZhanRule() area.Rule {
+	rule := new(XueZhanRule)
+	rule.name = "血战到底"
+	rule.base_rule = base_rule.NewBaseRule(false, false, false)
+	return rule
+}
+
+func (m *XueZhanRule) IsJiang(card int32) bool {
+	return m.base_rule.IsJiang(card)
+}
+
+func (m *XueZhanRule) MinFan() int {
+	return m.base_rule.MinFan()
+}
+
+func (m *XueZhanRule) HasHun() bool {
+	return m.base_rule.HasHun()
+}
+
+func (m *XueZhanRule) HasWind() bool {
+	return m.base_rule.HasWind()
+}
+
+// 血战只能四个人打
+func (m *XueZhanRule) ValidPlayerNum(player_num int) bool {
+	return player_num == 4
+}
+
+func (m *XueZhanRule) WallCards(player_num int) []int32 {
+	return m.base_rule.WallCards(player_num)
+}
+
+func (m *XueZhanRule) HandCardNum() int {
+	return m.base_rule.HandCardNum()
+}
+
+func (m *XueZhanRule) DeadWallNum() int {
+	return m.base_rule.DeadWallNum()
+}
+
+func (m *XueZhanRule) SelectHunCard(draw func() int32) (int32, int32) {
+	return m.base_rule.SelectHunCard(draw)
+}
+
+func (m *XueZhanRule) HasDingQue() bool {
+	return true
+}
+
+func (m *XueZhanRule) WinnerNum(player_num int) int {
+	return player_num - 1
+}
+
+func (m *XueZhanRule) IsQue(player *proto.Player, card int32) bool {
+	return player.Que != 0 && int32(utils.Card(card).Suit()) == player.Que
+}
+
+func (m *XueZhanRule) HasQue(player *proto.Player) bool {
+	for _, card := range player.Cards {
+		if m.IsQue(player, card) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *XueZhanRule) CanHu(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	card := disCard.Card
+	if player.CancelHu || m.IsQue(player, card) {
+		return false
+	}
+	if _, ok := player.PrewinCards[card]; !ok {
+		return false
+	}
+	req.Type = req.Type | proto.OperatType_HuOperat
+	req.HuReq.Card = card
+	if disCard.FromUid == player.Uid {
+		req.HuReq.Type = proto.HuType_Mo
+		if disCard.DisType == utils.DisCard_SelfGang {
+			req.HuReq.Type = proto.HuType_GangHua
+		}
+		if disCard.DisType == utils.DisCard_HaiDi {
+			req.HuReq.Type = proto.HuType_HaiDiLao
+		}
+	} else {
+		req.HuReq.Lose = disCard.FromUid
+		if disCard.DisType == utils.DisCard_BuGang {
+			req.HuReq.Type = proto.HuType_QiangGang
+		}
+	}
+	return true
+}
+
+func (m *XueZhanRule) CanEat(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	return false
+}
+
+func (m *XueZhanRule) CanAnGang(player *proto.Player, req *proto.OperatReq) bool {
+	ret := false
+	record := []int32{}
+	for _, card := range player.Cards {
+		if m.IsQue(player, card) || utils.Contain(record, card) {
+			continue
+		}
+		record = append(record, card)
+		if utils.Count(player.Cards, card) < 4 {
+			continue
+		}
+		req.Type = req.Type | proto.OperatType_GangOperat
+		gang := &proto.Gang{
+			Cards: []int32{card, card, card, card},
+			Type:  proto.GangType_AnGang,
+		}
+		req.GangReq.Gang = append(req.GangReq.Gang, gang)
+		ret = true
+	}
+	return ret
+}
+
+func (m *XueZhanRule) CanBuGang(player *proto.Player, req *proto.OperatReq) bool {
+	ret := false
+	for _, wave := range player.Waves {
+		if wave.WaveType != proto.Wave_PongWave {
+			continue
+		}
+		if utils.Count(player.Cards, wave.Cards[0]) > 0 {
+			req.Type = req.Type | proto.OperatType_GangOperat
+			gang := &proto.Gang{
+				Cards: []int32{wave.Cards[0]},
+				Type:  proto.GangType_BuGang,
+			}
+			req.GangReq.Gang = append(req.GangReq.Gang, gang)
+			ret = true
+		}
+	}
+	return ret
+}
+
+func (m *XueZhanRule) CanMingGang(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	if disCard.FromUid == player.Uid || m.IsQue(player, disCard.Card) {
+		return false
+	}
+	card := disCard.Card
+	if utils.Count(player.Cards, card) > 2 {
+		req.Type = req.Type | proto.OperatType_GangOperat
+		gang := &proto.Gang{
+			Cards: []int32{card, card, card},
+			Type:  proto.GangType_MingGang,
+		}
+		req.GangReq.Gang = append(req.GangReq.Gang, gang)
+		return true
+	}
+	return false
+}
+
+func (m *XueZhanRule) CanPong(disCard utils.DisCard, player *proto.Player, req *proto.OperatReq) bool {
+	if disCard.FromUid == player.Uid || m.IsQue(player, disCard.Card) {
+		return false
+	}
+	card := disCard.Card
+	if utils.Count(player.Cards, card) > 1 {
+		req.Type = req.Type | proto.OperatType_PongOperat
+		req.PongReq.Card = card
+		return true
+	}
+	return false
+}
+
+// 手里有缺门的牌必须先打缺门
+func (m *XueZhanRule) CanDrop(player *proto.Player, card int32) bool {
+	if m.IsQue(player, card) {
+		return true
+	}
+	return !m.HasQue(player)
+}
+
+func (m *XueZhanRule) Hu(player *proto.Player, huRsp *proto.HuRsp) {
+
+}
+
+func (m *XueZhanRule) Score(ctx *area.Context, ting area.Ting, huRsp *proto.HuRsp) (int, []string) {
+	return m.base_rule.Score(ctx, ting, huRsp)
+}
+
+// 一门牌能否全部组成刻子或者顺子
+func (m *XueZhanRule) IsAllCombine(counts [10]int) bool {
+	for v := 1; v < 10; v++ {
+		if counts[v] == 0 {
+			continue
+		}
+		if counts[v] >= 3 {
+			counts[v] -= 3
+			if m.IsAllCombine(counts) {
+				return true
+			}
+			counts[v] += 3
+		}
+		if v < 8 && counts[v+1] > 0 && counts[v+2] > 0 {
+			counts[v]--
+			counts[v+1]--
+			counts[v+2]--
+			return m.IsAllCombine(counts)
+		}
+		return false
+	}
+	return true
+}
+
+func (m *XueZhanRule) IsPair7(counts utils.Hand, wave_num int) bool {
+	if wave_num != 0 {
+		return false
+	}
+	pairs := 0
+	for t := 1; t < 4; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v]%2 != 0 {
+				return false
+			}
+			pairs += counts[t][v] / 2
+		}
+	}
+	return pairs == 7
+}
+
+func (m *XueZhanRule) IsHu(counts utils.Hand, wave_num int) bool {
+	if m.IsPair7(counts, wave_num) {
+		return true
+	}
+	for t := 1; t < 4; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v] < 2 {
+				continue
+			}
+			counts[t][v] -= 2
+			hu := true
+			for i := 1; i < 4; i++ {
+				if !m.IsAllCombine(counts[i]) {
+					hu = false
+					break
+				}
+			}
+			counts[t][v] += 2
+			if hu {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 碰碰胡: 除了将全部是刻子
+func (m *XueZhanRule) IsPengPengHu(counts utils.Hand) bool {
+	pairs := 0
+	for t := 1; t < 4; t++ {
+		for v := 1; v < 10; v++ {
+			switch counts[t][v] {
+			case 0, 3:
+			case 2:
+				pairs++
+			default:
+				return false
+			}
+		}
+	}
+	return pairs == 1
+}
+
+func (m *XueZhanRule) IsQingYiSe(player *proto.Player, card int32) bool {
+	s := utils.Card(card).Suit()
+	for _, c := range player.Cards {
+		if utils.Card(c).Suit() != s {
+			return false
+		}
+	}
+	for _, wave := range player.Waves {
+		if utils.Card(wave.Cards[0]).Suit() != s {
+			return false
+		}
+	}
+	return true
+}
+
+// 根: 四张一样的牌(包括杠)
+func (m *XueZhanRule) GenNum(counts utils.Hand, player *proto.Player) int {
+	num := 0
+	for _, wave := range player.Waves {
+		if wave.WaveType == proto.Wave_GangWave {
+			num++
+		} else if wave.WaveType == proto.Wave_PongWave {
+			for _, card := range wave.Cards {
+				counts.Add(utils.Card(card))
+			}
+		}
+	}
+	for t := 1; t < 4; t++ {
+		for v := 1; v < 10; v++ {
+			if counts[t][v] == 4 {
+				num++
+			}
+		}
+	}
+	return num
+}
+
+func (m *XueZhanRule) GetTingCards(ctx *area.Context) ([]int32, []int32, map[int32]interface{}) {
+	player := ctx.Player
+	result := make(map[int32]interface{})
+	if m.HasQue(player) || !utils.IsTingCardNum(len(player.Cards)) {
+		return player.NeedHun, player.NeedHunWithEye, result
+	}
+	counts := utils.NewHand(player.Cards)
+	for s := utils.SuitWan; s <= utils.SuitTiao; s++ {
+		if int32(s) == player.Que {
+			continue
+		}
+		for v := int32(1); v <= s.MaxRank(); v++ {
+			card := int32(utils.NewCard(s, v))
+			if counts[s][v] == 4 {
+				continue
+			}
+			counts[s][v]++
+			if m.IsHu(counts, len(player.Waves)) {
+				ting := NewTing(card)
+				ting.qingyise = m.IsQingYiSe(player, card)
+				ting.pair_7 = m.IsPair7(counts, len(player.Waves))
+				ting.pengpeng_hu = !ting.pair_7 && m.IsPengPengHu(counts)
+				ting.gen = m.GenNum(counts, player)
+				result[card] = ting
+			}
+			counts[s][v]--
+		}
+	}
+	m.base_rule.SetWaitShape(player, result)
+	return player.NeedHun, player.NeedHunWithEye, result
+}
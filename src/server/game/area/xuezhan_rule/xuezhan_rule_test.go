@@ -0,0 +1,41 @@
+package xuezhan_rule
+
+import (
+	"server/game/area/areatest"
+	"server/proto"
+	"testing"
+)
+
+var ting_cases = []areatest.Case{
+	{Hand: "1112345678999m", Waits: "123456789m",
+		Tings: "1m:QingYiSe,Gen,DuiDao 2m:QingYiSe,DanDiao 3m:QingYiSe,BianZhang 4m:QingYiSe 5m:QingYiSe,DanDiao 6m:QingYiSe 7m:QingYiSe,BianZhang 8m:QingYiSe,DanDiao 9m:QingYiSe,Gen,DuiDao"},
+	{Hand: "123m456p789s1155s", Waits: "15s", Tings: "1s:DuiDao 5s:DuiDao"},
+	{Hand: "1133m5577p99s114s", Waits: "4s", Tings: "4s:Pair7,DanDiao"},
+	{Hand: "1111m2255p3399s4s", Waits: "4s", Tings: "4s:LongPair7,DanDiao"},
+	{Hand: "1m", Waves: []string{"111p", "222p", "3333s", "555s"}, Waits: "1m", Tings: "1m:PengPengHu,Gen,QuanQiuRen,DanDiao"},
+	// 手里还有缺门的牌不能胡
+	{Hand: "123m456p789s1155s", Que: 1, Waits: "", Tings: ""},
+	{Hand: "123p456p789s1155s", Que: 1, Waits: "15s", Tings: "1s:DuiDao 5s:DuiDao"},
+}
+
+func TestTingCases(t *testing.T) {
+	rule := NewXueZhanRule()
+	for _, c := range ting_cases {
+		if err := c.Check(rule); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	rule := NewXueZhanRule()
+	// 血战没有混, 四组面子一对将不限将, 或者七对
+	win := func(player *proto.Player, cards []int32, card int32) bool {
+		counts, hun_num := areatest.CountCards(cards, 0)
+		any := func(card int32) bool { return true }
+		return areatest.IsStandard(counts, hun_num, any) || len(player.Waves) == 0 && areatest.IsPair7(counts, hun_num)
+	}
+	if err := areatest.CrossCheck(rule, 1, 20000, nil, win); err != nil {
+		t.Error(err)
+	}
+}
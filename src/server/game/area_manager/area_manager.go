@@ -5,25 +5,45 @@ import (
 	"fmt"
 	"reflect"
 	"math"
+	"sync"
 	"github.com/jxbdlut/leaf/log"
 	"server/game/area/default_rule"
+	"server/game/area/guangdong_rule"
+	"server/game/area/hongzhonglaizi_rule"
+	"server/game/area/xuezhan_rule"
 	"server/game/area"
+	"server/gamedata"
 )
 
 var (
 	ruleInfo      []*RuleInfo
 	ruleID        map[reflect.Type]uint16
+	catalogs      []*area.Catalog
+	catalogMutex  sync.RWMutex
 )
 
 type RuleInfo struct {
 	AreaType reflect.Type
+	Rule     area.Rule
+	Name     string
 }
-func Init() {
+// 番型表是唯一的计番来源, 加载不了就返回错误, 不能不计番接着跑
+func Init() error {
+	// 重复调用的时候从头注册, 区域编号不变
+	ruleInfo = nil
 	ruleID = make(map[reflect.Type]uint16)
-	Register(&default_rule.DefaultRule{})
+	Register(default_rule.NewDefaultRule(), "推倒胡")
+	Register(xuezhan_rule.NewXueZhanRule(), "血战到底")
+	Register(guangdong_rule.NewGuangDongRule(), "广东推倒胡")
+	Register(hongzhonglaizi_rule.NewHongZhongLaiZiRule(), "红中癞子")
+	if err := ReloadCatalog(); err != nil {
+		return fmt.Errorf("load fan catalog: %v", err)
+	}
+	return nil
 }
 
-func Register(rule area.Rule) error {
+// 区域号就是注册的顺序, 客户端建桌的时候用, 只能往后加
+func Register(rule area.Rule, name string) error {
 	ruleType := reflect.TypeOf(rule)
 	if _, ok := ruleID[ruleType]; ok {
 		return errors.New(fmt.Sprintf("%v has areadly register", rule))
@@ -33,15 +53,65 @@ func Register(rule area.Rule) error {
 	}
 	i := new(RuleInfo)
 	i.AreaType = ruleType
+	i.Rule = rule
+	i.Name = name
 	ruleInfo = append(ruleInfo, i)
 	ruleID[ruleType] = uint16(len(ruleInfo) - 1)
 	return nil
 }
 
+// 规则是无状态的, 同一个区域的所有桌子共用一个规则实例
 func GetArea(rule_id uint16) area.Rule {
-	switch rule_id {
-	case 0:
-		return default_rule.NewDefaultRule()
+	if int(rule_id) < len(ruleInfo) {
+		return ruleInfo[rule_id].Rule
+	}
+	return ruleInfo[0].Rule
+}
+
+func GetRuleId(rule area.Rule) uint16 {
+	return ruleID[reflect.TypeOf(rule)]
+}
+
+func AreaNum() int {
+	return len(ruleInfo)
+}
+
+func GetAreaName(rule_id uint16) string {
+	if int(rule_id) < len(ruleInfo) {
+		return ruleInfo[rule_id].Name
 	}
-	return default_rule.NewDefaultRule()
+	return ruleInfo[0].Name
+}
+
+// 从gamedata/Fan.txt重新加载番型表, 控制台reloadfan出错的时候保留原来的番型表
+func ReloadCatalog() error {
+	fans, err := gamedata.LoadFans()
+	if err != nil {
+		return err
+	}
+	tmp := make([]*area.Catalog, len(ruleInfo))
+	for i := range ruleInfo {
+		var defs []*area.FanDef
+		for _, fan := range fans {
+			if fan.Enabled(i) {
+				defs = append(defs, &area.FanDef{Id: fan.Id, Name: fan.Name, Value: fan.Value, Exclusive: fan.Exclusive, Exclude: fan.Exclude})
+			}
+		}
+		tmp[i] = area.NewCatalog(defs)
+	}
+	catalogMutex.Lock()
+	catalogs = tmp
+	catalogMutex.Unlock()
+	log.Release("load fan catalog, %v fans", len(fans))
+	return nil
+}
+
+// 没有加载番型表的时候返回nil, 这时候规则不计番
+func GetCatalog(rule_id uint16) *area.Catalog {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+	if int(rule_id) < len(catalogs) {
+		return catalogs[rule_id]
+	}
+	return nil
 }
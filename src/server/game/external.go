@@ -5,8 +5,14 @@ import (
 )
 
 var (
-	Module       = new(internal.Module)
-	ChanRPC      = internal.ChanRPC
-	MapUidPlayer = internal.MapUidPlayer
-	Tables       = internal.Tables
+	Module     = new(internal.Module)
+	ChanRPC    = internal.ChanRPC
+	FindPlayer = internal.FindPlayer
+	Simulate   = internal.Simulate
+)
+
+// 全机器人模拟, 见internal/simulation.go
+type (
+	SimConfig = internal.SimConfig
+	SimReport = internal.SimReport
 )
\ No newline at end of file
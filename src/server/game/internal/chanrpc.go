@@ -1,21 +1,30 @@
 package internal
 
 import (
+	"fmt"
 	"github.com/jxbdlut/leaf/gate"
 	"github.com/jxbdlut/leaf/log"
-	"server/userdata"
+	"server/game/area"
 	"server/game/area_manager"
+	"server/proto"
+	"server/userdata"
+	"server/utils"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func init() {
 	skeleton.RegisterChanRPC("NewRobot", rpcNewAgent)
 	skeleton.RegisterChanRPC("CloseAgent", rpcCloseAgent)
-	area_manager.Init()
+	skeleton.RegisterCommand("reloadfan", "reload fan catalog from gamedata", commandReloadFan)
+	skeleton.RegisterCommand("hand", "show hands of a table: hand <tid>", commandHand)
+	skeleton.RegisterCommand("ting", "show waits of a hand: ting <area> <hun|-> <hand>, e.g. ting 0 5z 123m456p789s11z + hun x2", commandTing)
 }
 
 func rpcNewAgent(args []interface{}) {
 	a := args[0].(gate.Agent)
-	_ = a
+	touchAgent(a)
 }
 
 func rpcCloseAgent(args []interface{}) {
@@ -26,15 +35,101 @@ func rpcCloseAgent(args []interface{}) {
 	if a == nil {
 		return
 	}
+	forgetAgent(a)
 	if a.UserData() == nil {
 		return
 	}
 	uid := a.UserData().(*userdata.UserData).Uid
 	tid := a.UserData().(*userdata.UserData).Tid
-	if table, ok := Tables[tid]; ok {
+	if table, ok := getTable(tid); ok {
 		//table.RemoveAgent(a)
 		//a.Destroy()
 		table.OfflineAgent(a)
 	}
 	log.Debug("close agent uid: %v, tid:%v", uid, tid)
 }
+
+func commandReloadFan(args []interface{}) interface{} {
+	if err := area_manager.ReloadCatalog(); err != nil {
+		return err.Error()
+	}
+	return "ok"
+}
+
+func commandHand(args []interface{}) interface{} {
+	if len(args) != 1 {
+		return "usage: hand <tid>"
+	}
+	tid, err := strconv.ParseUint(args[0].(string), 10, 32)
+	if err != nil {
+		return err.Error()
+	}
+	table, ok := getTable(uint32(tid))
+	if !ok {
+		return fmt.Sprintf("table %v not found", tid)
+	}
+	var lines []string
+	for _, p := range table.Players() {
+		line := fmt.Sprintf("uid:%v, %v %v", p.uid, utils.HandNotation(p.cards, table.hun_card), utils.CardsGlyph(p.cards, table.hun_card))
+		for _, wave := range p.waves {
+			line += " " + utils.CardsNotation(wave.Cards)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 手牌用简写或者麻将字符, 混用 "+ hun xN" 或者 🀪, 没有混的时候hun写成-
+func commandTing(args []interface{}) interface{} {
+	if len(args) < 3 {
+		return "usage: ting <area> <hun|-> <hand>"
+	}
+	area_id, err := strconv.ParseUint(args[0].(string), 10, 16)
+	if err != nil {
+		return err.Error()
+	}
+	var hun_card int32
+	if args[1].(string) != "-" {
+		cards, err := utils.ParseCards(args[1].(string))
+		if err != nil || len(cards) != 1 {
+			return fmt.Sprintf("invalid hun %q", args[1])
+		}
+		hun_card = cards[0]
+	}
+	var words []string
+	for _, arg := range args[2:] {
+		words = append(words, arg.(string))
+	}
+	cards, err := utils.ParseHand(strings.Join(words, " "), hun_card)
+	if err != nil {
+		return err.Error()
+	}
+	if !utils.IsTingCardNum(len(cards)) {
+		return fmt.Sprintf("%v cards can not ting", len(cards))
+	}
+	utils.SortCards(cards, hun_card)
+	player := &proto.Player{
+		Cards:          cards,
+		HunCard:        hun_card,
+		NeedHun:        []int32{4, 4, 4, 4},
+		NeedHunWithEye: []int32{4, 4, 4, 4},
+		IsNeedUpdate:   []bool{true, true, true, true},
+		PrewinCards:    make(map[int32]*proto.PreWinCard),
+	}
+	rule := area_manager.GetArea(uint16(area_id))
+	_, _, result := rule.GetTingCards(area.NewContext(player, area_manager.GetCatalog(uint16(area_id))))
+	var keys []int32
+	for key := range result {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	var tings []string
+	for _, key := range keys {
+		if ting, ok := result[key].(area.Ting); ok {
+			tings = append(tings, ting.Info())
+		} else {
+			tings = append(tings, utils.CardNotation(key))
+		}
+	}
+	return fmt.Sprintf("%v %v 听[%v]", utils.HandNotation(cards, hun_card), utils.CardsGlyph(cards, hun_card), strings.Join(tings, ","))
+}
@@ -1,29 +1,27 @@
 package internal
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/jxbdlut/leaf/gate"
 	"github.com/jxbdlut/leaf/log"
 	"net"
+	"sync"
+	"server/conf"
 	"server/proto"
 	"server/userdata"
 	"server/game/area_manager"
 )
 
-const (
-	MinTableId uint32 = 10000
-	MaxTableId uint32 = 100000
-	MinRobotId uint64 = 100000
-	MaxRobotId uint64 = 1000000
-)
-
 var (
 	Tables       map[uint32]*Table
 	robots       map[uint64]*gate.Agent
-	curTableId   uint32 = 10000
-	curRobotId   uint64 = 100000
+	curTableId   uint32
+	curRobotId   uint64
 	MapUidPlayer map[uint64]*Player
+	// Tables和MapUidPlayer在skeleton, login和每张桌子的goroutine里都会用到
+	mutex sync.RWMutex
 )
 
 func init() {
@@ -31,6 +29,8 @@ func init() {
 	handler(&proto.JoinTableReq{}, handlerJoinTable)
 	handler(&proto.OperatRsp{}, handlerOperatRsp)
 	handler(&proto.TableOperatRsp{}, handlerTableOperatRsp)
+	handler(&proto.TrusteeReq{}, handlerTrustee)
+	handler(&proto.GetAreaReq{}, handlerGetArea)
 	Tables = make(map[uint32]*Table)
 	robots = make(map[uint64]*gate.Agent)
 	MapUidPlayer = make(map[uint64]*Player)
@@ -40,6 +40,45 @@ func handler(m interface{}, h interface{}) {
 	skeleton.RegisterChanRPC(reflect.TypeOf(m), h)
 }
 
+func getTable(tid uint32) (*Table, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	table, ok := Tables[tid]
+	return table, ok
+}
+
+func addTable(table *Table) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	Tables[table.tid] = table
+}
+
+func delTable(tid uint32) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(Tables, tid)
+}
+
+// 还在桌子上的玩家, 登录的时候用来判断要不要恢复
+func FindPlayer(uid uint64) (*Player, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	player, ok := MapUidPlayer[uid]
+	return player, ok
+}
+
+func addPlayer(player *Player) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	MapUidPlayer[player.uid] = player
+}
+
+func delPlayer(uid uint64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(MapUidPlayer, uid)
+}
+
 func checkLogin(a gate.Agent) bool {
 	if a.UserData() == nil || 0 == a.UserData().(*userdata.UserData).Uid {
 		return false
@@ -52,6 +91,7 @@ func handlerCreateTable(args []interface{}) {
 	req := args[0].(*proto.CreateTableReq)
 	a := args[1].(gate.Agent)
 	seq := args[2].(uint32)
+	touchAgent(a)
 	if !checkLogin(a) {
 		log.Error("no login!")
 		a.Replay(&proto.CreateTableRsp{
@@ -61,10 +101,30 @@ func handlerCreateTable(args []interface{}) {
 		return
 	}
 	uid := a.UserData().(*userdata.UserData).Uid
+	area_id := uint16(conf.Server.Game.DefaultArea)
+	if req.AreaSet {
+		area_id = uint16(req.Area)
+	}
+	rule := area_manager.GetArea(area_id)
+	player_num := int(req.PlayerNum)
+	if player_num == 0 {
+		player_num = conf.Server.Game.DefaultPlayerNum
+	}
+	if !rule.ValidPlayerNum(player_num) {
+		log.Error("uid:%v, invalid player num:%v, rule:%v", uid, player_num, reflect.TypeOf(rule))
+		a.Replay(&proto.CreateTableRsp{
+			ErrCode: -1,
+			ErrMsg:  "invalid player num!",
+		}, seq)
+		return
+	}
 	tid := genTableId()
-	log.Debug("uid:%v, create table, tid:%v, seq:%v", uid, tid, seq)
-	table := NewTable(tid, proto.CreateTableReq_TableType(req.Type))
-	table.rule = area_manager.GetArea(uint16(req.Area))
+	log.Debug("uid:%v, create table, tid:%v, player num:%v, seq:%v", uid, tid, player_num, seq)
+	table := NewTable(tid, proto.CreateTableReq_TableType(req.Type), player_num)
+	table.SetRule(rule)
+	if conf.Server.Game.WallSeed != 0 {
+		table.SetSeed(conf.Server.Game.WallSeed)
+	}
 	log.Debug("tid:%v, rule:%v", tid, reflect.TypeOf(table.rule))
 	a.SetUserData(&userdata.UserData{
 		Uid: uid,
@@ -72,15 +132,26 @@ func handlerCreateTable(args []interface{}) {
 	})
 	table.AddAgent(a, true)
 	if proto.CreateTableReq_TableType(req.Type) == proto.CreateTableReq_TableRobot {
-		for i := 1; i < 4; i++ {
+		robot_num := player_num - 1
+		if conf.Server.Game.RobotNum > 0 && conf.Server.Game.RobotNum < robot_num {
+			robot_num = conf.Server.Game.RobotNum
+		}
+		for i := 0; i < robot_num; i++ {
 			rid := genRobotUid()
 			agent := NewAgent(rid)
+			if _, err := table.AddAgent(agent, false); err != nil {
+				log.Error("tid:%v, add robot:%v, err:%v", tid, rid, err)
+				break
+			}
 			robots[rid] = &agent
-			table.AddAgent(agent, false)
+			// 难度按机器人入座的顺序给, 按uid找到它实际坐的位置
+			if player, err := table.GetPlayer(rid); err == nil && i < len(req.RobotLevels) {
+				setRobotLevel(player, req.RobotLevels[i])
+			}
 		}
 	}
+	addTable(table)
 	go table.Run()
-	Tables[tid] = table
 	a.Replay(&proto.CreateTableRsp{
 		ErrCode: 0,
 		ErrMsg:  "CreateTable success!",
@@ -92,6 +163,7 @@ func handlerJoinTable(args []interface{}) {
 	req := args[0].(*proto.JoinTableReq)
 	a := args[1].(gate.Agent)
 	seq := args[2].(uint32)
+	touchAgent(a)
 	rsp := proto.JoinTableRsp{}
 	if !checkLogin(a) {
 		log.Error("no login!")
@@ -104,7 +176,7 @@ func handlerJoinTable(args []interface{}) {
 	uid := a.UserData().(*userdata.UserData).Uid
 	tid := req.TableId
 	log.Debug("uid:%v, join table, tid:%v, seq:%v", uid, tid, seq)
-	if table, ok := Tables[tid]; ok {
+	if table, ok := getTable(tid); ok {
 		if _, err := table.GetPlayerIndex(uid); err == nil {
 			rsp.ErrCode = 10000
 			rsp.ErrMsg = "you areadly in table"
@@ -113,16 +185,16 @@ func handlerJoinTable(args []interface{}) {
 				Uid: uid,
 				Tid: tid,
 			})
-			if pos, err := Tables[tid].AddAgent(a, false); err != nil {
+			if pos, err := table.AddAgent(a, false); err != nil {
 				rsp.ErrCode = -1
 				rsp.ErrMsg = err.Error()
 			} else {
 				rsp.ErrCode = 0
 				rsp.ErrMsg = "join success!"
 				rsp.Pos = int32(pos)
-				joinTableMsg := proto.UserJoinTableMsg{Tid:tid}
-				for i, player := range Tables[tid].players {
-					seat := &proto.Seat{Uid:player.uid, Name:player.name, Pos:int32(i + 1)}
+				joinTableMsg := proto.UserJoinTableMsg{Tid:tid, Dealer:table.DealerUid(), RoundWind:table.round_wind}
+				for i, player := range table.Players() {
+					seat := &proto.Seat{Uid:player.uid, Name:player.name, Pos:int32(i + 1), Wind:table.SeatWind(i)}
 					joinTableMsg.Seats = append(joinTableMsg.Seats, seat)
 				}
 				table.Broadcast(&joinTableMsg)
@@ -137,34 +209,97 @@ func handlerJoinTable(args []interface{}) {
 	a.Replay(&rsp, seq)
 }
 
+// 回复都在agent.SendRcv里按seq收走了, 到这里的是超时托管之后才回的, 没有人等, 丢掉
 func handlerTableOperatRsp(args []interface{}) {
 	rsp := args[0]
 	a := args[1].(gate.Agent)
-	tid := a.UserData().(*userdata.UserData).Tid
-	uid := a.UserData().(*userdata.UserData).Uid
-	table := Tables[tid]
-	if player, err := table.GetPlayer(uid); err == nil {
-		player.HandlerTableOperatRsp(rsp)
+	touchAgent(a)
+	if !checkLogin(a) {
+		return
 	}
+	log.Debug("uid:%v, late %v, drop", a.UserData().(*userdata.UserData).Uid, reflect.TypeOf(rsp))
 }
 
+// 回复都在agent.SendRcv里按seq收走了, 到这里的是超时托管之后才回的, 没有人等, 丢掉
 func handlerOperatRsp(args []interface{}) {
 	rsp := args[0]
 	a := args[1].(gate.Agent)
-	tid := a.UserData().(*userdata.UserData).Tid
+	touchAgent(a)
+	if !checkLogin(a) {
+		return
+	}
+	log.Debug("uid:%v, late %v, drop", a.UserData().(*userdata.UserData).Uid, reflect.TypeOf(rsp))
+}
+
+func handlerTrustee(args []interface{}) {
+	req := args[0].(*proto.TrusteeReq)
+	a := args[1].(gate.Agent)
+	seq := args[2].(uint32)
+	touchAgent(a)
+	if !checkLogin(a) {
+		log.Error("no login!")
+		a.Replay(&proto.TrusteeRsp{
+			ErrCode: -1,
+			ErrMsg:  "no login!",
+		}, seq)
+		return
+	}
 	uid := a.UserData().(*userdata.UserData).Uid
-	table := Tables[tid]
-	if player, err := table.GetPlayer(uid); err == nil {
-		player.HandlerOperatRsp(rsp)
+	player, ok := FindPlayer(uid)
+	if !ok {
+		a.Replay(&proto.TrusteeRsp{
+			ErrCode: -1,
+			ErrMsg:  "not in table",
+		}, seq)
+		return
 	}
+	log.Debug("uid:%v, trustee:%v, seq:%v", uid, req.On, seq)
+	player.SetTrustee(req.On)
+	a.Replay(&proto.TrusteeRsp{
+		ErrCode: 0,
+		On:      req.On,
+	}, seq)
+}
+
+// 不用登录也能查, 人数只列2到4人里规则支持的
+func handlerGetArea(args []interface{}) {
+	a := args[1].(gate.Agent)
+	seq := args[2].(uint32)
+	touchAgent(a)
+	default_area := uint16(conf.Server.Game.DefaultArea)
+	rsp := &proto.GetAreaRsp{
+		AreaId:   int32(default_area),
+		AreaName: area_manager.GetAreaName(default_area),
+	}
+	for i := 0; i < area_manager.AreaNum(); i++ {
+		info := &proto.AreaInfo{AreaId: int32(i), AreaName: area_manager.GetAreaName(uint16(i))}
+		for player_num := 2; player_num <= 4; player_num++ {
+			if area_manager.GetArea(uint16(i)).ValidPlayerNum(player_num) {
+				info.PlayerNums = append(info.PlayerNums, int32(player_num))
+			}
+		}
+		rsp.Areas = append(rsp.Areas, info)
+	}
+	a.Replay(rsp, seq)
+}
+
+// 配置里只能检查非负, 有几个区域要等area_manager.Init之后才知道
+func checkAreaConf() error {
+	if conf.Server.Game.DefaultArea >= area_manager.AreaNum() {
+		return fmt.Errorf("Game.DefaultArea: must be less than %v, got %v", area_manager.AreaNum(), conf.Server.Game.DefaultArea)
+	}
+	return nil
 }
 
 func genTableId() uint32 {
+	if curTableId < conf.Server.Game.MinTableId || curTableId > conf.Server.Game.MaxTableId {
+		curTableId = conf.Server.Game.MinTableId
+	}
 	for {
-		if _, ok := Tables[curTableId]; ok {
+		if _, ok := getTable(curTableId); ok {
 			curTableId++
-			if curTableId > MaxTableId {
-				curTableId = MinTableId
+			if curTableId > conf.Server.Game.MaxTableId {
+				curTableId = conf.Server.Game.MinTableId
 			}
 		} else {
 			return curTableId
@@ -172,12 +307,26 @@ func genTableId() uint32 {
 	}
 }
 
+func setRobotLevel(player *Player, level proto.CreateTableReq_RobotLevel) {
+	name, ok := robotNameOfLevel(level)
+	if !ok {
+		log.Error("uid:%v, unknown robot level:%v, use default", player.uid, level)
+		return
+	}
+	if err := player.SetRobot(name); err != nil {
+		log.Error("uid:%v, %v", player.uid, err)
+	}
+}
+
 func genRobotUid() uint64 {
+	if curRobotId < conf.Server.Game.MinRobotId || curRobotId > conf.Server.Game.MaxRobotId {
+		curRobotId = conf.Server.Game.MinRobotId
+	}
 	for {
 		if _, ok := robots[curRobotId]; ok {
 			curRobotId++
-			if curRobotId > MaxRobotId {
-				curRobotId = MinRobotId
+			if curRobotId > conf.Server.Game.MaxRobotId {
+				curRobotId = conf.Server.Game.MinRobotId
 			}
 		} else {
 			return curRobotId
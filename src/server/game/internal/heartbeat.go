@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"github.com/jxbdlut/leaf/gate"
+	"github.com/jxbdlut/leaf/log"
+	"server/conf"
+	"server/proto"
+	"server/userdata"
+	"sync"
+	"time"
+)
+
+var (
+	// 每个连接最后一次收到消息的时间, 桌子的goroutine收到操作回复的时候也会更新
+	lastActive  = make(map[gate.Agent]time.Time)
+	activeMutex sync.Mutex
+)
+
+func init() {
+	handler(&proto.HeartbeatReq{}, handlerHeartbeat)
+}
+
+func nowMs() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+func touchAgent(a gate.Agent) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	lastActive[a] = time.Now()
+}
+
+// 操作回复在agent.SendRcv里收走了, 不经过handler, 收到的时候在这里记一下.
+// 已经断开的连接不再加回来
+func keepAlive(a gate.Agent) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	if _, ok := lastActive[a]; ok {
+		lastActive[a] = time.Now()
+	}
+}
+
+func forgetAgent(a gate.Agent) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	delete(lastActive, a)
+}
+
+func handlerHeartbeat(args []interface{}) {
+	req := args[0].(*proto.HeartbeatReq)
+	a := args[1].(gate.Agent)
+	seq := args[2].(uint32)
+	touchAgent(a)
+	a.Replay(&proto.HeartbeatRsp{
+		ClientTime: req.ClientTime,
+		ServerTime: nowMs(),
+	}, seq)
+}
+
+// 半开的连接收不到close, 超时没有消息就主动断开, 断开之后走CloseAgent的掉线流程
+func checkIdle() {
+	timeout := time.Duration(conf.Server.HeartbeatTimeout) * time.Second
+	if timeout <= 0 {
+		return
+	}
+	now := time.Now()
+	var idle []gate.Agent
+	activeMutex.Lock()
+	for a, last := range lastActive {
+		if now.Sub(last) > timeout {
+			var uid uint64
+			if a.UserData() != nil {
+				uid = a.UserData().(*userdata.UserData).Uid
+			}
+			log.Release("uid:%v, no heartbeat for %v, close", uid, now.Sub(last))
+			delete(lastActive, a)
+			idle = append(idle, a)
+		}
+	}
+	activeMutex.Unlock()
+	for _, a := range idle {
+		a.Close()
+	}
+	skeleton.AfterFunc(timeout/2, checkIdle)
+}
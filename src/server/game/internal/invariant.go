@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/jxbdlut/leaf/log"
+	"server/proto"
+	"server/utils"
+	"sort"
+)
+
+// 牌局不变量检查, 按桌子打开, 打开之后每个动作做完都检查一遍:
+// 牌墙+翻牌+手牌+花牌+吃碰杠+打牌记录的牌数守恒, 手牌数是3n+1或者3n+2, 吃碰杠都是合法的组合
+// 吃碰明杠拿走的牌在打牌记录里还留着, 点炮胡的牌不在任何地方, 这两种单独记账
+type InvariantChecker struct {
+	table    *Table
+	strict   bool    // 出错直接panic, 模拟和fuzz用
+	all      []int32 // 这一局洗牌之前的全部牌
+	claimed  []int32 // 吃碰明杠拿走的别人打出的牌
+	consumed []int32 // 点炮胡掉的牌, 一炮多响只算一张
+	Errors   []error
+}
+
+func (t *Table) EnableCheck(strict bool) *InvariantChecker {
+	t.checker = &InvariantChecker{table: t, strict: strict}
+	return t.checker
+}
+
+func (t *Table) Check(action string) {
+	if t.checker != nil {
+		t.checker.Check(action)
+	}
+}
+
+func (m *InvariantChecker) Reset(all_cards []int32) {
+	m.all = utils.Copy(all_cards)
+	m.claimed = m.claimed[:0]
+	m.consumed = m.consumed[:0]
+}
+
+func (m *InvariantChecker) Claim(card int32) {
+	m.claimed = append(m.claimed, card)
+}
+
+func (m *InvariantChecker) Consume(card int32) {
+	m.consumed = append(m.consumed, card)
+}
+
+func (m *InvariantChecker) Check(action string) {
+	for _, err := range m.check() {
+		err = fmt.Errorf("tid:%v, after %v, %v", m.table.tid, action, err)
+		log.Error("%v", err)
+		m.Errors = append(m.Errors, err)
+		if m.strict {
+			panic(err)
+		}
+	}
+}
+
+func (m *InvariantChecker) check() []error {
+	var errs []error
+	t := m.table
+	counts := make(map[int32]int)
+	add := func(cards []int32, n int) {
+		for _, card := range cards {
+			counts[card] += n
+		}
+	}
+	add(m.all, -1)
+	add(t.left_cards, 1)
+	// 翻混的牌从死端摸出来亮在桌上
+	if t.fan_card != 0 {
+		add([]int32{t.fan_card}, 1)
+	}
+	for _, cards := range t.drop_record {
+		add(cards, 1)
+	}
+	add(m.claimed, -1)
+	add(m.consumed, 1)
+	for _, player := range t.players {
+		add(player.cards, 1)
+		add(player.flowers, 1)
+		for _, wave := range player.waves {
+			add(wave.Cards, 1)
+		}
+		if err := m.checkPlayer(player); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	var diff []string
+	for card, num := range counts {
+		if num != 0 {
+			diff = append(diff, fmt.Sprintf("%v:%+d", utils.CardStr(card), num))
+		}
+	}
+	if len(diff) > 0 {
+		sort.Strings(diff)
+		errs = append(errs, fmt.Errorf("tile count drift %v", diff))
+	}
+	return errs
+}
+
+// 杠算一组面子, 手牌加面子是HandCardNum或者多一张
+func (m *InvariantChecker) checkPlayer(p *Player) error {
+	hand_num := m.table.rule.HandCardNum()
+	melds := 0
+	for _, wave := range p.waves {
+		if err := checkWave(wave); err != nil {
+			return fmt.Errorf("uid:%v, %v", p.uid, err)
+		}
+		if wave.WaveType != proto.Wave_GangWave || wave.GangType != proto.GangType_SpecialGang {
+			melds++
+		}
+	}
+	num := len(p.cards) + 3*melds
+	if len(p.cards)%3 == 0 || (num != hand_num && num != hand_num+1) {
+		return fmt.Errorf("uid:%v, %v cards in hand with %v melds", p.uid, len(p.cards), melds)
+	}
+	for _, card := range p.cards {
+		if utils.IsFlower(card) {
+			return fmt.Errorf("uid:%v, flower %v in hand", p.uid, utils.CardStr(card))
+		}
+	}
+	return nil
+}
+
+func checkWave(wave *proto.Wave) error {
+	cards := wave.Cards
+	same := func(num int) bool {
+		if len(cards) != num {
+			return false
+		}
+		for _, card := range cards {
+			if card != cards[0] {
+				return false
+			}
+		}
+		return true
+	}
+	switch wave.WaveType {
+	case proto.Wave_PongWave:
+		if same(3) {
+			return nil
+		}
+	case proto.Wave_GangWave:
+		if wave.GangType == proto.GangType_SpecialGang || same(4) {
+			return nil
+		}
+	case proto.Wave_EatWave:
+		if len(cards) == 3 {
+			sorted := utils.Copy(cards)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			suit := sorted[0] / 100
+			if suit >= 1 && suit <= 3 && sorted[1] == sorted[0]+1 && sorted[2] == sorted[0]+2 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("illegal wave %v %v", wave.WaveType, wave.Cards)
+}
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"encoding/binary"
+	"errors"
+	"server/conf"
+	"server/game/area_manager"
+	"server/proto"
+	"sync"
+	"testing"
+)
+
+var initAreaOnce sync.Once
+
+func initArea() {
+	// 测试里没有番型表, 不计番
+	initAreaOnce.Do(func() { area_manager.Init() })
+}
+
+// fuzz的输入一个字节一个字节地用, 用完之后机器人按baseline正常回答, 保证牌局能打完
+type fuzzInput struct {
+	data []byte
+	pos  int
+}
+
+func (m *fuzzInput) next() (int, bool) {
+	if m.pos >= len(m.data) {
+		return 0, false
+	}
+	m.pos++
+	return int(m.data[m.pos-1]), true
+}
+
+func (m *fuzzInput) intn(n int) int {
+	b, _ := m.next()
+	return b % n
+}
+
+// 乱回答的机器人: 回复的类型, 牌, 吃碰杠的组合都可能是错的, 也可能直接返回错误
+type fuzzRobot struct {
+	*BaseRobot
+	input *fuzzInput
+}
+
+var fuzzOperatTypes = []proto.OperatType{
+	proto.OperatType_DealOperat,
+	proto.OperatType_DrawOperat,
+	proto.OperatType_HuOperat,
+	proto.OperatType_EatOperat,
+	proto.OperatType_PongOperat,
+	proto.OperatType_GangOperat,
+	proto.OperatType_DropOperat,
+	proto.OperatType_DingQueOperat,
+}
+
+func (a *fuzzRobot) HandlerMsg(req interface{}) (interface{}, error) {
+	operat, ok := req.(*proto.OperatReq)
+	b, more := a.input.next()
+	if !ok || !more || b%4 == 0 {
+		return a.BaseRobot.HandlerMsg(req)
+	}
+	if b%4 == 1 {
+		return nil, errors.New("fuzz error")
+	}
+	rsp, _ := a.BaseRobot.HandlerOperatMsg(operat)
+	if b%4 == 2 {
+		rsp.Type = fuzzOperatTypes[a.input.intn(len(fuzzOperatTypes))]
+	}
+	rsp.HuRsp.Ok = a.input.intn(2) == 0
+	rsp.PongRsp.Ok = a.input.intn(2) == 0
+	rsp.GangRsp.Ok = a.input.intn(2) == 0
+	rsp.EatRsp.Ok = a.input.intn(2) == 0
+	rsp.DropRsp.DisCard = a.card()
+	rsp.DingQueRsp.Suit = int32(a.input.intn(5))
+	switch a.input.intn(5) {
+	case 0:
+		rsp.HuRsp.Card, rsp.PongRsp.Card = a.card(), a.card()
+	case 1:
+		rsp.GangRsp.Gang, rsp.EatRsp.Eat = nil, nil
+	case 2:
+		rsp.GangRsp.Gang = &proto.Gang{Cards: []int32{a.card()}, Type: proto.GangType(a.input.intn(4))}
+		rsp.EatRsp.Eat = &proto.Eat{HandCard: []int32{a.card(), a.card()}, WaveCard: []int32{a.card(), a.card(), a.card()}}
+	case 3:
+		// 网络上来的回复可能缺字段
+		rsp.HuRsp, rsp.PongRsp, rsp.GangRsp, rsp.EatRsp, rsp.DropRsp, rsp.DingQueRsp = nil, nil, nil, nil, nil, nil
+	}
+	if a.input.intn(16) == 0 {
+		rsp.ErrCode, rsp.ErrMsg = -1, "fuzz"
+	}
+	return rsp, nil
+}
+
+// 一半是手上的牌, 一半是随便的牌
+func (a *fuzzRobot) card() int32 {
+	cards := a.player.cards
+	if b := a.input.intn(256); b < 128 && len(cards) > 0 {
+		return cards[b%len(cards)]
+	}
+	return int32(a.input.intn(5)+1)*100 + int32(a.input.intn(10))
+}
+
+func newFuzzTable(area_id uint16, player_num int, seed int64, input *fuzzInput) *Table {
+	t := NewTable(0, proto.CreateTableReq_TableRobot, player_num)
+	t.SetRule(area_manager.GetArea(area_id))
+	t.SetSeed(seed)
+	t.EnableCheck(true)
+	for i := 0; i < player_num; i++ {
+		uid := conf.Server.Game.MinRobotId + uint64(i)
+		player := NewPlayer(NewAgent(uid), uid)
+		player.isRobot = true
+		player.online = true
+		player.SetTable(t)
+		player.robot = &fuzzRobot{BaseRobot: NewRobot(player), input: input}
+		t.players = append(t.players, player)
+	}
+	return t
+}
+
+// 第一个字节是区域和人数, 接着8个字节是种子, 后面的字节决定机器人怎么乱回答, 不变量出错会panic
+func FuzzTablePlay(f *testing.F) {
+	initArea()
+	f.Add([]byte{8, 1, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{9, 2, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3, 5, 6, 7, 9, 10, 11})
+	f.Add([]byte{10, 3, 0, 0, 0, 0, 0, 0, 0, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2})
+	f.Add([]byte{11, 4, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	// 两人和三人
+	f.Add([]byte{0, 5, 0, 0, 0, 0, 0, 0, 0, 3, 1, 4, 1, 5, 9, 2, 6})
+	f.Add([]byte{6, 6, 0, 0, 0, 0, 0, 0, 0, 2, 7, 1, 8, 2, 8})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) < 9 {
+			return
+		}
+		area_id := uint16(data[0] % 4)
+		player_num := 2 + int(data[0]/4%3)
+		if !area_manager.GetArea(area_id).ValidPlayerNum(player_num) {
+			return
+		}
+		seed := int64(binary.LittleEndian.Uint64(data[1:9]))
+		table := newFuzzTable(area_id, player_num, seed, &fuzzInput{data: data[9:]})
+		table.Clear()
+		table.Play()
+	})
+}
+
+func TestInvariantSimulation(t *testing.T) {
+	initArea()
+	for area_id := uint16(0); area_id < 4; area_id++ {
+		report, err := Simulate(SimConfig{Tables: 20, Hands: 2, Seed: 1, Area: area_id,
+			Robots: []string{RobotDefensive, RobotGreedy, RobotBaseline, RobotRandom}, Check: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range report.Panics {
+			t.Errorf("area:%v, seed:%v, hand:%v, %v", area_id, p.Seed, p.Hand, p.Err)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package internal
 
 import (
+	"github.com/jxbdlut/leaf/log"
 	"github.com/jxbdlut/leaf/module"
 	"server/base"
+	"server/game/area_manager"
 )
 
 var (
@@ -16,6 +18,17 @@ type Module struct {
 
 func (m *Module) OnInit() {
 	m.Skeleton = skeleton
+	// 放在这里而不是init, 番型表的路径要等配置加载完
+	if err := area_manager.Init(); err != nil {
+		log.Fatal("%v", err)
+	}
+	if err := checkRobotConf(); err != nil {
+		log.Fatal("%v", err)
+	}
+	if err := checkAreaConf(); err != nil {
+		log.Fatal("%v", err)
+	}
+	checkIdle()
 }
 
 func (m *Module) OnDestroy() {
@@ -8,10 +8,14 @@ import (
 	"net"
 	"reflect"
 	"server/game/area"
+	"server/game/area_manager"
+	"server/conf"
 	"server/proto"
+	"server/userdata"
 	"server/utils"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,6 +24,7 @@ type Player struct {
 	uid               uint64
 	name              string
 	cards             []int32
+	flowers           []int32
 	separate_result   [5][]int32
 	cancel_hu         bool
 	waves             []*proto.Wave
@@ -28,41 +33,77 @@ type Player struct {
 	is_need_update    []bool
 	prewin_cards      map[int32]interface{}
 	win_card          int32
+	lose_uid          uint64 // 点炮的人, 自摸是0
+	fan               int
+	fan_names         []string
+	que               int32
 	master            bool
 	online            bool
+	trustee           bool
 	table             *Table
 	robot             robot
 	isRobot           bool
 	timeout           time.Duration
+	mutex             sync.Mutex // agent, online, trustee, timeout: 重连/掉线/托管在skeleton和login里改, 桌子的goroutine里读
 }
 
-var (
-	rsp_chan = make(chan interface{})
-)
-
 func NewPlayer(agent gate.Agent, uid uint64) *Player {
 	p := new(Player)
 	p.agent = agent
 	p.uid = uid
 	p.win_card = 0
 	p.cancel_hu = false
-	p.robot = NewRobot(p)
-	p.timeout = 10 * time.Second
-	if MinRobotId <= uid && uid <= MaxRobotId {
+	p.timeout = time.Duration(conf.Server.Game.OperatTimeout)
+	// 机器人座位用机器人桌的默认策略, 真人掉线托管的时候用另外配置的策略
+	robot_name := conf.Server.Game.OfflineRobot
+	if conf.Server.Game.MinRobotId <= uid && uid <= conf.Server.Game.MaxRobotId {
 		p.isRobot = true
+		robot_name = conf.Server.Game.TableRobot
+	}
+	if err := p.SetRobot(robot_name); err != nil {
+		log.Error("uid:%v, %v, use %v", uid, err, RobotBaseline)
+		p.robot = NewRobot(p)
 	}
 	return p
 }
 
+func (p *Player) SetRobot(name string) error {
+	r, err := NewRobotByName(name, p)
+	if err != nil {
+		return err
+	}
+	p.robot = r
+	return nil
+}
+
 func (p *Player) InitNeedHun() {
 	p.need_hun = append(p.need_hun[:0], []int32{4, 4, 4, 4}...)
 	p.need_hun_with_eye = append(p.need_hun_with_eye[:0], []int32{4, 4, 4, 4}...)
 	p.is_need_update = append(p.is_need_update[:0], []bool{true, true, true, true}...)
 }
 
+// 重连, 掉线期间的托管也一起取消
 func (p *Player) SetAgent(agent gate.Agent) {
+	if p.table != nil {
+		// 登录的时候只填了uid, 补上桌号, 不然再掉线的时候找不到桌子
+		agent.SetUserData(&userdata.UserData{Uid: p.uid, Tid: p.table.tid})
+	}
+	p.mutex.Lock()
 	p.agent = agent
-	p.SetOnline(true)
+	p.online = true
+	p.trustee = false
+	p.timeout = time.Duration(conf.Server.Game.OperatTimeout)
+	p.mutex.Unlock()
+	p.BroadcastStatus(proto.PlayerStatus_StatusReconnect)
+}
+
+func (p *Player) BroadcastStatus(status proto.PlayerStatus) {
+	if p.table == nil {
+		return
+	}
+	msg := &proto.PlayerStatusMsg{Uid: p.uid, Status: status}
+	log.Release("tid:%v, %v", p.table.tid, msg.Info())
+	p.table.BroadcastExceptMe(msg, p.uid)
 }
 
 func (p *Player) String() string {
@@ -92,8 +133,14 @@ func (p *Player) String() string {
 		str = str + strings.Join(tmp, ",")
 		str = str + "]"
 	}
+	if len(p.flowers) > 0 {
+		str = str + "花" + utils.CardsStr(p.flowers)
+	}
 	if p.win_card != 0 {
 		str = str + "->" + utils.CardStr(p.win_card) + " 胡牌!"
+		if len(p.fan_names) > 0 {
+			str = str + fmt.Sprintf(" %v番[%v]", p.fan, strings.Join(p.fan_names, ","))
+		}
 	}
 	return str
 }
@@ -107,6 +154,14 @@ func (p *Player) GetProtoPlayer() *proto.Player {
 	}
 	player.HunCard = p.table.hun_card
 	player.CancelHu = p.cancel_hu
+	player.Que = p.que
+	player.Flowers = append(player.Flowers, p.flowers...)
+	player.Dealer = p.table.DealerUid()
+	player.SeatWind = p.SeatWind()
+	player.RoundWind = p.table.round_wind
+	player.LianZhuang = int32(p.table.lian_zhuang)
+	wall := p.table.wall
+	player.Wall = &wall
 	player.DropCards = append(player.DropCards, p.table.drop_record[p.uid]...)
 	player.Waves = append(player.Waves, p.waves...)
 	player.NeedHun = append(player.NeedHun, p.need_hun...)
@@ -128,8 +183,13 @@ func (p *Player) GetProtoPlayer() *proto.Player {
 func (p *Player) Clear() {
 	p.cards = append(p.cards[:0])
 	p.waves = append(p.waves[:0])
+	p.flowers = p.flowers[:0]
 	p.prewin_cards = make(map[int32]interface{})
 	p.win_card = 0
+	p.lose_uid = 0
+	p.fan = 0
+	p.fan_names = nil
+	p.que = 0
 	p.cancel_hu = false
 	p.separate_result = [5][]int32{}
 	p.InitNeedHun()
@@ -137,32 +197,15 @@ func (p *Player) Clear() {
 
 func (p *Player) FeedCard(cards []int32) {
 	p.Operat()
-	p.cards = append(p.cards, cards...)
-	utils.SortCards(p.cards, p.table.hun_card)
-	p.separate_result = utils.SeparateCards(p.cards, p.table.hun_card)
-}
-
-func (p *Player) isNotPengPengHu(need_hun_arr []*Ting) bool {
-	for _, wave := range p.waves {
-		if wave.WaveType == proto.Wave_EatWave {
-			return true
-		}
-	}
-
-	shunzi_count := 0
-	for _, ting := range need_hun_arr {
-		if ting.shunzi_count > 0 {
-			shunzi_count = shunzi_count + 1
+	for _, card := range cards {
+		if utils.IsFlower(card) {
+			p.flowers = append(p.flowers, card)
+		} else {
+			p.cards = append(p.cards, card)
 		}
 	}
-	if shunzi_count > 1 {
-		return true
-	}
-	return false
-}
-
-func (p *Player) isQingYiSe() bool {
-	return true
+	utils.SortCards(p.cards, p.table.hun_card)
+	p.separate_result = utils.SeparateCards(p.cards, p.table.hun_card)
 }
 
 func (p *Player) AddGangWave(cards []int32, t proto.GangType) {
@@ -209,6 +252,8 @@ func (p *Player) DelCards(cards []int32) {
 	for _, card := range cards {
 		if index, err := p.GetCardIndex(card); err == nil {
 			p.cards = append(p.cards[:index], p.cards[index+1:]...)
+		} else {
+			log.Error("uid:%v, del card:%v, not in hand", p.uid, card)
 		}
 	}
 	p.separate_result = utils.SeparateCards(p.cards, p.table.hun_card)
@@ -222,11 +267,33 @@ func (p *Player) DelCard(card int32) {
 		}
 	}
 	p.separate_result = utils.SeparateCards(p.cards, p.table.hun_card)
-	p.need_hun, p.need_hun_with_eye, p.prewin_cards = p.table.rule.GetTingCards(p.GetProtoPlayer())
-	p.ClearUpdate()
+	p.UpdateTingCards()
 	log.Release("%v", p)
 }
 
+// 重新计算听牌, 只改自己的数据, 不同座位可以并行
+func (p *Player) UpdateTingCards() {
+	p.need_hun, p.need_hun_with_eye, p.prewin_cards = p.table.rule.GetTingCards(p.NewContext())
+	p.ClearUpdate()
+}
+
+func (p *Player) NewContext() *area.Context {
+	return area.NewContext(p.GetProtoPlayer(), area_manager.GetCatalog(p.table.area))
+}
+
+// 胡的那张牌对应的听牌信息, 特殊牌型(腾空, 飘将等)挂在1-4上
+func (p *Player) HuTing(card int32) area.Ting {
+	if ting, ok := p.prewin_cards[card].(area.Ting); ok {
+		return ting
+	}
+	for key := int32(1); key <= 4; key++ {
+		if ting, ok := p.prewin_cards[key].(area.Ting); ok {
+			return ting
+		}
+	}
+	return nil
+}
+
 func (p *Player) ResetUpdate() {
 	for i := 0; i < len(p.is_need_update); i++ {
 		p.is_need_update[i] = true
@@ -254,6 +321,11 @@ func (p *Player) Deal() {
 	req.DealReq.Cards = p.cards
 	req.DealReq.FanCard = p.table.fan_card
 	req.DealReq.HunCard = p.table.hun_card
+	req.DealReq.Flowers = p.flowers
+	req.DealReq.Dealer = p.table.DealerUid()
+	req.DealReq.SeatWind = p.SeatWind()
+	req.DealReq.RoundWind = p.table.round_wind
+	req.DealReq.LianZhuang = int32(p.table.lian_zhuang)
 	rsp, err := p.Notify(req)
 	if err != nil {
 		log.Error("uid:%v, Deal err:%v", p.uid, err)
@@ -269,10 +341,67 @@ func (p *Player) Deal() {
 	return
 }
 
+// 发牌之后补花, 花牌放到一边, 从牌墙补到起手张数为止
+func (p *Player) BuHua() {
+	for len(p.cards) < p.table.rule.HandCardNum() {
+		p.FeedCard([]int32{p.table.DrawDeadCard()})
+	}
+}
+
+// 定缺, 返回需要广播的消息
+func (p *Player) DingQue() *proto.OperatMsg {
+	req := proto.NewOperatReq()
+	req.Type = proto.OperatType_DingQueOperat
+	req.DingQueReq.Suit = p.SuggestQue()
+	p.que = req.DingQueReq.Suit
+	operatMsg := proto.NewOperatMsg()
+	operatMsg.Uid = p.uid
+	operatMsg.Type = proto.OperatType_DingQueOperat
+	operatMsg.DingQue.Suit = p.que
+	rsp, err := p.Notify(req)
+	if err != nil {
+		log.Error("uid:%v, DingQue err:%v", p.uid, err)
+		return operatMsg
+	}
+	result, err := p.ValidRsp(req, rsp.(*proto.OperatRsp))
+	if err != nil {
+		log.Error("uid:%v, dingque rsp invalid err:%v, rsp:%v", p.uid, err, rsp.(*proto.OperatRsp).Info())
+		return operatMsg
+	}
+	p.que = result.(*proto.DingQueRsp).Suit
+	operatMsg.DingQue.Suit = p.que
+	log.Release("uid:%v, %v, %v", p.uid, req.Info(), rsp.(*proto.OperatRsp).Info())
+	return operatMsg
+}
+
+// 推荐张数最少的一门作为缺门
+func (p *Player) SuggestQue() int32 {
+	suit := int32(1)
+	for t := int32(2); t < 4; t++ {
+		if len(p.separate_result[t]) < len(p.separate_result[suit]) {
+			suit = t
+		}
+	}
+	return suit
+}
+
+func (p *Player) SeatWind() int32 {
+	pos, err := p.table.GetPlayerIndex(p.uid)
+	if err != nil {
+		return 0
+	}
+	return p.table.SeatWind(pos)
+}
+
+func (p *Player) IsWin() bool {
+	return p.win_card != 0
+}
+
 // card为零的情况是为了防止吃或者碰之后出错，要随机出一张牌
 func (p *Player) Drop(disCard utils.DisCard) utils.DisCard {
 	card := disCard.Card
 	operatMsg := proto.NewOperatMsg()
+	operatMsg.Uid = p.uid
 	req := proto.NewOperatReq()
 	req.Type = proto.OperatType_DropOperat
 	p.CanGang(disCard, req)
@@ -280,31 +409,42 @@ func (p *Player) Drop(disCard utils.DisCard) utils.DisCard {
 	if disCard.Card == 0 {
 		disCard.FromUid = p.uid
 		disCard.DisType = utils.DisCard_Normal
-		disCard.Card = p.cards[len(p.cards)-1]
+	}
+	disCard.Card = p.AutoDropCard(disCard.Card)
+	// 出错或者放弃胡杠又没有打牌, 就替玩家打出默认的那张, 这张牌也要从手上删掉
+	auto_drop := func() utils.DisCard {
 		operatMsg.Type = operatMsg.Type | proto.OperatType_DropOperat
 		operatMsg.Drop.DisCard = disCard.Card
+		p.table.Broadcast(operatMsg)
+		p.SetUpdate(disCard.Card)
+		p.SetUpdate(card)
+		p.DelCard(disCard.Card)
+		return disCard
 	}
 	rsp, err := p.Notify(req)
 	if err != nil {
 		log.Error("uid:%v, Drop err:%v", p.uid, err)
-		p.table.Broadcast(operatMsg)
-		p.SetUpdate(disCard.Card)
-		return disCard
+		return auto_drop()
 	}
 	log.Release("uid:%v, %v, %v", p.uid, req.Info(), rsp.(*proto.OperatRsp).Info())
 	result, err := p.ValidRsp(req, rsp.(*proto.OperatRsp))
 	if err != nil {
-		log.Error("uid:%v ValidRsp err:%v", err)
-		p.table.Broadcast(operatMsg)
-		p.SetUpdate(disCard.Card)
-		return disCard
+		log.Error("uid:%v ValidRsp err:%v", p.uid, err)
+		return auto_drop()
 	}
-	p.BoardCastMsg(rsp.(*proto.OperatRsp))
 	switch rsp.(*proto.OperatRsp).Type {
 	case proto.OperatType_HuOperat:
+		if !result.(*proto.HuRsp).Ok {
+			return auto_drop()
+		}
+		p.BoardCastMsg(rsp.(*proto.OperatRsp))
 		p.Hu(result.(*proto.HuRsp))
 		disCard.Card = 0
 	case proto.OperatType_GangOperat:
+		if !result.(*proto.GangRsp).Ok {
+			return auto_drop()
+		}
+		p.BoardCastMsg(rsp.(*proto.OperatRsp))
 		p.Gang(result.(*proto.GangRsp).Gang.Cards, result.(*proto.GangRsp).Gang.Type)
 		if result.(*proto.GangRsp).Gang.Type == proto.GangType_BuGang {
 			disCard.Card = result.(*proto.GangRsp).Gang.Cards[0]
@@ -317,6 +457,7 @@ func (p *Player) Drop(disCard utils.DisCard) utils.DisCard {
 		}
 		return p.Draw(utils.DisCard_SelfGang)
 	case proto.OperatType_DropOperat:
+		p.BoardCastMsg(rsp.(*proto.OperatRsp))
 		disCard.Card = result.(*proto.DropRsp).DisCard
 		if disCard.Card != card {
 			p.SetUpdate(disCard.Card)
@@ -328,51 +469,41 @@ func (p *Player) Drop(disCard utils.DisCard) utils.DisCard {
 }
 
 func (p *Player) Draw(cardType utils.DisCardType) utils.DisCard {
-	card := p.table.DrawCard()
+	// 杠牌从死端补
+	var card int32
+	if cardType == utils.DisCard_SelfGang {
+		card = p.table.DrawDeadCard()
+	} else {
+		card = p.table.DrawCard()
+	}
+	// 摸到花牌就从死端补花, 一直补到不是花牌为止
+	var flowers []int32
+	for utils.IsFlower(card) {
+		flowers = append(flowers, card)
+		card = p.table.DrawDeadCard()
+	}
+	if len(flowers) > 0 {
+		p.FeedCard(flowers)
+		p.BoardCastMsg(&proto.FlowerMsg{Uid: p.uid, Flowers: flowers})
+	}
 	p.FeedCard([]int32{card})
 	disCard := utils.DisCard{Card: card, FromUid: p.uid, DisType: cardType}
 	req := proto.NewOperatReq()
 	req.Type = proto.OperatType_DrawOperat
 	req.DrawReq.Card = card
+	req.DrawReq.Flowers = flowers
+	// 摸牌的回复只是确认, 出错也要接着让玩家打牌, 不然摸到的牌会同时留在手上和打牌记录里
 	rsp, err := p.Notify(req)
 	if err != nil {
 		log.Error("uid:%v Draw err:%v", p.uid, err)
-		return disCard
+		return p.Drop(disCard)
 	}
 	log.Release("uid:%v, %v, %v", p.uid, req.Info(), rsp.(*proto.OperatRsp).Info())
 	log.Release("%v", p)
-	result, err := p.ValidRsp(req, rsp.(*proto.OperatRsp))
-	_ = result
-	if err != nil {
-		return disCard
-	}
-	switch rsp.(*proto.OperatRsp).Type {
-	case proto.OperatType_DrawOperat:
-		disCard = p.Drop(disCard)
-	}
-	return disCard
-}
-
-func (p *Player) HandlerOperatRsp(msg interface{}) {
-	//log.Debug("uid:%v HandlerOperatRsp:%v", p.uid, msg)
-	rsp_chan <- msg
-}
-
-func (p *Player) HandlerTableOperatRsp(msg interface{}) {
-	//log.Debug("uid:%v HandlerTableOperatRsp:%v", p.uid, msg)
-	rsp_chan <- msg
-}
-
-func (p *Player) SendRcv(msg interface{}) (interface{}, error) {
-	p.Send(msg)
-	select {
-	case msg := <-rsp_chan:
-		p.SetOnline(true)
-		return msg, nil
-	case <-time.After(p.timeout * time.Second):
-		p.SetOnline(false)
-		return nil, errors.New("time out")
+	if _, err := p.ValidRsp(req, rsp.(*proto.OperatRsp)); err != nil {
+		log.Error("uid:%v Draw rsp err:%v", p.uid, err)
 	}
+	return p.Drop(disCard)
 }
 
 func (p *Player) BoardCastMsg(msg interface{}) {
@@ -400,31 +531,88 @@ func (p *Player) BoardCastMsg(msg interface{}) {
 		p.table.Broadcast(operatMsg)
 	} else if reflect.TypeOf(msg) == reflect.TypeOf(&proto.TableOperatMsg{}) {
 		p.table.BroadcastExceptMe(msg, p.uid)
+	} else if reflect.TypeOf(msg) == reflect.TypeOf(&proto.FlowerMsg{}) {
+		p.table.BroadcastExceptMe(msg, p.uid)
 	}
 }
 
 func (p *Player) Notify(req interface{}) (interface{}, error) {
+	rsp, err := p.notify(req)
+	if err != nil {
+		return nil, err
+	}
+	return checkRsp(req, rsp)
+}
+
+func (p *Player) notify(req interface{}) (interface{}, error) {
 	//time.Sleep(2 * time.Second)
 	if p.isRobot {
 		return p.robot.HandlerMsg(req)
 	}
-	if p.online {
-		rsp, err := p.agent.SendRcv(req)
+	agent, online, trustee, timeout := p.status()
+	if online && !trustee {
+		rsp, err := p.sendRcv(agent, req, timeout)
 		if err != nil {
 			log.Error("uid:%v, Notify err:%v", p.uid, err)
 			return nil, err
 		}
 		return rsp, nil
 	} else {
+		// 掉线之后机器人接管, 通知一下同桌
+		p.SetTrustee(true)
 		p.Send(req)
 		return p.robot.HandlerMsg(req)
 	}
 	return nil, errors.New("online error")
 }
 
+// 最多等OperatTimeout秒, 没回就托管, 之后机器人代打, 玩家自己取消托管再接着问他
+func (p *Player) sendRcv(agent gate.Agent, req interface{}, timeout time.Duration) (interface{}, error) {
+	type result struct {
+		rsp interface{}
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		rsp, err := agent.SendRcv(req)
+		ch <- result{rsp, err}
+	}()
+	select {
+	case r := <-ch:
+		if r.err == nil {
+			keepAlive(agent)
+		}
+		return r.rsp, r.err
+	case <-time.After(timeout * time.Second):
+		p.SetTrustee(true)
+		// 自己也要知道被托管了, 不然还会接着回复
+		p.Send(&proto.PlayerStatusMsg{Uid: p.uid, Status: proto.PlayerStatus_StatusTrustee})
+		return nil, errors.New("time out")
+	}
+}
+
+// 回复的类型要和请求对应上, 操作回复缺的字段补成空的, 调用的地方直接类型断言
+func checkRsp(req interface{}, rsp interface{}) (interface{}, error) {
+	switch req.(type) {
+	case *proto.OperatReq:
+		operat_rsp, ok := rsp.(*proto.OperatRsp)
+		if !ok || operat_rsp == nil {
+			return nil, fmt.Errorf("want OperatRsp, got %v", reflect.TypeOf(rsp))
+		}
+		operat_rsp.FillEmpty()
+	case *proto.TableOperatReq:
+		if table_rsp, ok := rsp.(*proto.TableOperatRsp); !ok || table_rsp == nil {
+			return nil, fmt.Errorf("want TableOperatRsp, got %v", reflect.TypeOf(rsp))
+		}
+	}
+	return rsp, nil
+}
+
 func (p *Player) Hu(huRsp *proto.HuRsp) {
 	p.win_card = huRsp.Card
-	p.table.win_player = p
+	p.lose_uid = huRsp.Lose
+	p.fan, p.fan_names = p.table.rule.Score(p.NewContext(), p.HuTing(huRsp.Card), huRsp)
+	p.table.AddWinner(p)
 	log.Release("%v", p)
 	log.Release("uid:%v, %v", p.uid, huRsp.Info())
 	return
@@ -456,6 +644,10 @@ func (p *Player) Gang(gangCards []int32, gangType proto.GangType) {
 	p.DelCards(delCards)
 	p.AddGangWave(cards, gangType)
 	p.SetUpdate(cards[0])
+	if gangType == proto.GangType_MingGang && p.table.checker != nil {
+		p.table.checker.Claim(card)
+	}
+	p.table.Check("gang")
 }
 
 func (p *Player) Pong(card int32) {
@@ -464,6 +656,10 @@ func (p *Player) Pong(card int32) {
 	p.DelCards(cards)
 	p.AddPongWave(card)
 	p.SetUpdate(card)
+	if p.table.checker != nil {
+		p.table.checker.Claim(card)
+	}
+	p.table.Check("pong")
 }
 
 func (p *Player) Eat(eat *proto.Eat) {
@@ -471,6 +667,17 @@ func (p *Player) Eat(eat *proto.Eat) {
 	p.AddEatWave(eat.WaveCard)
 	p.DelCards(eat.HandCard)
 	p.SetUpdate(eat.WaveCard[0])
+	if p.table.checker != nil {
+		// 吃的那张是组合里去掉手上出的牌剩下的
+		claimed := utils.Copy(eat.WaveCard)
+		for _, card := range eat.HandCard {
+			claimed = utils.DelCountCard(claimed, card, 1)
+		}
+		for _, card := range claimed {
+			p.table.checker.Claim(card)
+		}
+	}
+	p.table.Check("eat")
 }
 
 func (p *Player) ValidRsp(req *proto.OperatReq, rsp *proto.OperatRsp) (interface{}, error) {
@@ -486,6 +693,12 @@ func (p *Player) ValidRsp(req *proto.OperatReq, rsp *proto.OperatRsp) (interface
 		if req.Type&proto.OperatType_DrawOperat != 0 {
 			return nil, nil
 		}
+	case proto.OperatType_DingQueOperat:
+		if req.Type&proto.OperatType_DingQueOperat != 0 {
+			if p.ValidDingQue(rsp.DingQueRsp) {
+				return rsp.DingQueRsp, nil
+			}
+		}
 	case proto.OperatType_DropOperat:
 		if req.Type&proto.OperatType_DropOperat != 0 {
 			if p.ValidDrop(rsp.DropRsp.DisCard) {
@@ -527,7 +740,16 @@ func (p *Player) CanHu(disCard utils.DisCard, req *proto.OperatReq) bool {
 	if disCard.Card == 0 {
 		return false
 	}
-	return p.table.rule.CanHu(disCard, p.GetProtoPlayer(), req)
+	if !p.table.rule.CanHu(disCard, p.GetProtoPlayer(), req) {
+		return false
+	}
+	// 听牌的时候按自摸算的起胡番, 这里按实际的胡法再算一遍
+	huRsp := &proto.HuRsp{Card: req.HuReq.Card, Type: req.HuReq.Type}
+	if !area.ReachMinFan(p.table.rule, p.NewContext(), p.HuTing(req.HuReq.Card), huRsp) {
+		req.Type = req.Type &^ proto.OperatType_HuOperat
+		return false
+	}
+	return true
 }
 
 func (p *Player) CheckHu(disCard utils.DisCard) bool {
@@ -574,11 +796,33 @@ func (p *Player) ValidDrop(card int32) bool {
 	if utils.Count(p.cards, card) == 0 {
 		return false
 	}
-	return true
+	return p.table.rule.CanDrop(p.GetProtoPlayer(), card)
+}
+
+// 替玩家打牌的时候打哪张, 也要过规则的CanDrop: 刚摸的能打就打刚摸的, 不然从手里最后一张往前找,
+// 血战手里还有缺门的时候只有缺门的牌能打
+func (p *Player) AutoDropCard(card int32) int32 {
+	player := p.GetProtoPlayer()
+	if card != 0 && p.table.rule.CanDrop(player, card) {
+		return card
+	}
+	for i := len(p.cards) - 1; i >= 0; i-- {
+		if p.table.rule.CanDrop(player, p.cards[i]) {
+			return p.cards[i]
+		}
+	}
+	return p.cards[len(p.cards)-1]
+}
+
+func (p *Player) ValidDingQue(rsp *proto.DingQueRsp) bool {
+	return 0 < rsp.Suit && rsp.Suit < 4
 }
 
 func (p *Player) ValidEat(req *proto.EatReq, rsp *proto.EatRsp) bool {
 	if rsp.Ok {
+		if rsp.Eat == nil {
+			return false
+		}
 		for _, can_eat := range req.Eat {
 			if can_eat.Equal(rsp.Eat) {
 				return true
@@ -601,6 +845,9 @@ func (p *Player) ValidPong(req *proto.PongReq, rsp *proto.PongRsp) bool {
 
 func (p *Player) ValidGang(req *proto.GangReq, rsp *proto.GangRsp) bool {
 	if rsp.Ok {
+		if rsp.Gang == nil {
+			return false
+		}
 		for _, can_gang := range req.Gang {
 			if can_gang.Equal(rsp.Gang) {
 				return true
@@ -741,24 +988,65 @@ func (p *Player) SetTable(t *Table) {
 }
 
 func (p *Player) SetOnline(online bool) {
+	p.mutex.Lock()
+	changed := p.online != online
 	p.online = online
 	if online {
-		p.timeout = 10
+		p.timeout = time.Duration(conf.Server.Game.OperatTimeout)
 	} else {
 		p.timeout = 0
 	}
+	p.mutex.Unlock()
+	if changed {
+		if online {
+			p.BroadcastStatus(proto.PlayerStatus_StatusOnline)
+		} else {
+			p.BroadcastStatus(proto.PlayerStatus_StatusOffline)
+		}
+	}
 }
 
 func (p *Player) GetOnline() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	return p.online
 }
 
+// 桌子的goroutine里一次操作开始的时候拿一份, 等回复的时候被改了也不影响这一次
+func (p *Player) status() (gate.Agent, bool, bool, time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.agent, p.online, p.trustee, p.timeout
+}
+
+func (p *Player) Agent() gate.Agent {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.agent
+}
+
+// 主动托管/取消托管, 已经在等的那次操作还是等玩家自己回
+func (p *Player) SetTrustee(on bool) {
+	p.mutex.Lock()
+	if p.trustee == on {
+		p.mutex.Unlock()
+		return
+	}
+	p.trustee = on
+	p.mutex.Unlock()
+	if on {
+		p.BroadcastStatus(proto.PlayerStatus_StatusTrustee)
+	} else {
+		p.BroadcastStatus(proto.PlayerStatus_StatusOnline)
+	}
+}
+
 func (p *Player) Replay(msg interface{}, seq uint32) {
-	p.agent.Replay(msg, seq)
+	p.Agent().Replay(msg, seq)
 }
 
 func (p *Player) Send(msg interface{}) {
-	p.agent.Send(msg)
+	p.Agent().Send(msg)
 }
 
 //func (p *Player) WriteMsg(msg interface{}, seq uint32) {
@@ -766,23 +1054,23 @@ func (p *Player) Send(msg interface{}) {
 //}
 
 func (p *Player) LocalAddr() net.Addr {
-	return p.agent.LocalAddr()
+	return p.Agent().LocalAddr()
 }
 
 func (p *Player) RemoteAddr() net.Addr {
-	return p.agent.RemoteAddr()
+	return p.Agent().RemoteAddr()
 }
 
 func (p *Player) Close() {
-	p.agent.Close()
+	p.Agent().Close()
 }
 
 func (p *Player) Destroy() {
-	p.agent.Destroy()
+	p.Agent().Destroy()
 }
 
 func (p *Player) UserData() interface{} {
-	return p.agent.UserData()
+	return p.Agent().UserData()
 }
 
 func (p *Player) SetUserData(data interface{}) {
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"server/conf"
+	"server/game/area_manager"
+	"server/proto"
+	"server/utils"
+	"testing"
+)
+
+func newTestPlayer(area_id uint16, hand string, que int32) *Player {
+	t := NewTable(0, proto.CreateTableReq_TableRobot, conf.Server.Game.DefaultPlayerNum)
+	t.SetRule(area_manager.GetArea(area_id))
+	uid := conf.Server.Game.MinRobotId
+	player := NewPlayer(NewAgent(uid), uid)
+	player.SetTable(t)
+	t.players = append(t.players, player)
+	cards, err := utils.ParseCards(hand)
+	if err != nil {
+		panic(err)
+	}
+	player.cards = cards
+	player.que = que
+	return player
+}
+
+// 替玩家打牌也要守规则: 血战手里有缺门的时候只能打缺门
+func TestAutoDropCard(t *testing.T) {
+	initArea()
+	cases := []struct {
+		area uint16
+		hand string
+		que  int32
+		card int32
+		want int32
+	}{
+		{0, "123m456p789s111z", 0, 401, 401},
+		{0, "123m456p789s111z", 0, 0, 401},
+		{1, "12399m456p789s", 0, 109, 109},
+		{1, "12399m456p789s", 3, 109, 309},
+		{1, "12399m456p789s", 3, 309, 309},
+		{1, "12399m456p789s", 1, 0, 109},
+		{1, "12399m456p789s", 2, 109, 206},
+	}
+	for _, c := range cases {
+		player := newTestPlayer(c.area, c.hand, c.que)
+		if got := player.AutoDropCard(c.card); got != c.want {
+			t.Errorf("area:%v, %v, que:%v, card:%v, got %v, want %v", c.area, c.hand, c.que, c.card, got, c.want)
+		}
+	}
+}
@@ -21,9 +21,26 @@ func NewRobot(p *Player) *BaseRobot {
 	return a
 }
 
+// 每种操作的决策, 不同的机器人实现这些方法, 消息分发共用
+type robotOperator interface {
+	HandlerTableOperatMsg(req *proto.TableOperatReq) (*proto.TableOperatRsp, error)
+	Deal(req *proto.DealReq, rsp *proto.DealRsp) bool
+	Hu(req *proto.HuReq, rsp *proto.HuRsp) bool
+	Draw(req *proto.DrawReq, rsp *proto.DrawRsp) bool
+	Gang(req *proto.GangReq, rsp *proto.GangRsp) bool
+	Pong(req *proto.PongReq, rsp *proto.PongRsp) bool
+	Eat(req *proto.EatReq, rsp *proto.EatRsp) bool
+	Drop(req *proto.DropReq, rsp *proto.DropRsp) bool
+	DingQue(req *proto.DingQueReq, rsp *proto.DingQueRsp) bool
+}
+
 func (a *BaseRobot) HandlerMsg(req interface{}) (interface{}, error) {
+	return handlerRobotMsg(a, req)
+}
+
+func handlerRobotMsg(a robotOperator, req interface{}) (interface{}, error) {
 	if reflect.TypeOf(req) == reflect.TypeOf(&proto.OperatReq{}) {
-		return a.HandlerOperatMsg(req.(*proto.OperatReq))
+		return handlerRobotOperatMsg(a, req.(*proto.OperatReq))
 	} else if reflect.TypeOf(req) == reflect.TypeOf(&proto.TableOperatReq{}) {
 		return a.HandlerTableOperatMsg(req.(*proto.TableOperatReq))
 	}
@@ -42,6 +59,10 @@ func (a *BaseRobot) HandlerTableOperatMsg(req *proto.TableOperatReq) (*proto.Tab
 }
 
 func (a *BaseRobot) HandlerOperatMsg(req *proto.OperatReq) (*proto.OperatRsp, error) {
+	return handlerRobotOperatMsg(a, req)
+}
+
+func handlerRobotOperatMsg(a robotOperator, req *proto.OperatReq) (*proto.OperatRsp, error) {
 	rsp := proto.NewOperatRsp()
 	if req.Type&proto.OperatType_DealOperat != 0 {
 		rsp.Type = proto.OperatType_DealOperat
@@ -64,6 +85,9 @@ func (a *BaseRobot) HandlerOperatMsg(req *proto.OperatReq) (*proto.OperatRsp, er
 	} else if req.Type&proto.OperatType_DropOperat != 0 {
 		rsp.Type = proto.OperatType_DropOperat
 		a.Drop(req.DropReq, rsp.DropRsp)
+	} else if req.Type&proto.OperatType_DingQueOperat != 0 {
+		rsp.Type = proto.OperatType_DingQueOperat
+		a.DingQue(req.DingQueReq, rsp.DingQueRsp)
 	}
 	return rsp, nil
 }
@@ -84,9 +108,18 @@ func (a *BaseRobot) Draw(req *proto.DrawReq, rsp *proto.DrawRsp) bool {
 	return true
 }
 
+func (a *BaseRobot) DingQue(req *proto.DingQueReq, rsp *proto.DingQueRsp) bool {
+	rsp.Suit = req.Suit
+	return true
+}
+
 func (a *BaseRobot) Drop(req *proto.DropReq, rsp *proto.DropRsp) bool {
 	cards_copy := utils.Copy(a.player.cards)
 	separate_result := utils.SeparateCards(cards_copy, a.player.table.hun_card)
+	if que := a.player.que; que != 0 && len(separate_result[que]) > 0 {
+		rsp.DisCard = separate_result[que][0]
+		return true
+	}
 	discard := utils.DropSingle(separate_result)
 	if discard == 0 {
 		discard = utils.DropRand(cards_copy, a.player.table.hun_card)
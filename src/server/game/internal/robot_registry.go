@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand"
+	"server/conf"
+	"server/proto"
+	"server/utils"
+	"sort"
+	"time"
+)
+
+// 机器人策略的名字, 配置文件里用的也是这些名字
+const (
+	RobotRandom    = "random"
+	RobotBaseline  = "baseline"
+	RobotGreedy    = "greedy"
+	RobotDefensive = "defensive"
+)
+
+type RobotCreator func(p *Player) robot
+
+var (
+	robotCreators = make(map[string]RobotCreator)
+	// 建桌时客户端选的难度对应的策略, 默认难度用配置里的TableRobot
+	robotLevels = map[proto.CreateTableReq_RobotLevel]string{
+		proto.CreateTableReq_RobotRandom:    RobotRandom,
+		proto.CreateTableReq_RobotBaseline:  RobotBaseline,
+		proto.CreateTableReq_RobotGreedy:    RobotGreedy,
+		proto.CreateTableReq_RobotDefensive: RobotDefensive,
+	}
+)
+
+func init() {
+	RegisterRobot(RobotRandom, func(p *Player) robot {
+		return NewRandomRobot(p)
+	})
+	RegisterRobot(RobotBaseline, func(p *Player) robot {
+		return NewRobot(p)
+	})
+	RegisterRobot(RobotGreedy, func(p *Player) robot {
+		a := NewSmartRobot(p)
+		a.defense = false
+		return a
+	})
+	RegisterRobot(RobotDefensive, func(p *Player) robot {
+		return NewSmartRobot(p)
+	})
+}
+
+func RegisterRobot(name string, creator RobotCreator) {
+	if _, ok := robotCreators[name]; ok {
+		panic(fmt.Sprintf("robot %v has areadly register", name))
+	}
+	robotCreators[name] = creator
+}
+
+func RobotNames() []string {
+	var names []string
+	for name := range robotCreators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func NewRobotByName(name string, p *Player) (robot, error) {
+	creator, ok := robotCreators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown robot %q, want one of %v", name, RobotNames())
+	}
+	return creator(p), nil
+}
+
+// 启动的时候检查配置里的策略名
+func checkRobotConf() error {
+	for _, name := range []string{conf.Server.Game.TableRobot, conf.Server.Game.OfflineRobot} {
+		if _, ok := robotCreators[name]; !ok {
+			return fmt.Errorf("unknown robot %q, want one of %v", name, RobotNames())
+		}
+	}
+	return nil
+}
+
+func robotNameOfLevel(level proto.CreateTableReq_RobotLevel) (string, bool) {
+	if level == proto.CreateTableReq_RobotDefault {
+		return conf.Server.Game.TableRobot, true
+	}
+	name, ok := robotLevels[level]
+	return name, ok
+}
+
+// 随机机器人: 能胡就胡, 吃碰杠一半一半, 有缺门先打缺门, 其他随便打
+type RandomRobot struct {
+	*BaseRobot
+	rand *rand.Rand
+}
+
+func NewRandomRobot(p *Player) *RandomRobot {
+	a := new(RandomRobot)
+	a.BaseRobot = NewRobot(p)
+	return a
+}
+
+// 第一次用的时候才从桌子的随机数取种子, 指定了桌子种子的模拟可以复现
+func (a *RandomRobot) random() *rand.Rand {
+	if a.rand == nil {
+		if table := a.player.table; table != nil && table.rand != nil {
+			a.rand = rand.New(rand.NewSource(table.rand.Int63()))
+		} else {
+			a.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+	}
+	return a.rand
+}
+
+func (a *RandomRobot) HandlerMsg(req interface{}) (interface{}, error) {
+	return handlerRobotMsg(a, req)
+}
+
+func (a *RandomRobot) Drop(req *proto.DropReq, rsp *proto.DropRsp) bool {
+	hun_card := a.player.table.hun_card
+	separate_result := utils.SeparateCards(utils.Copy(a.player.cards), hun_card)
+	if que := a.player.que; que != 0 && len(separate_result[que]) > 0 {
+		rsp.DisCard = separate_result[que][a.random().Intn(len(separate_result[que]))]
+		return true
+	}
+	var cards []int32
+	for _, card := range a.player.cards {
+		if card != hun_card {
+			cards = append(cards, card)
+		}
+	}
+	if len(cards) == 0 {
+		cards = a.player.cards
+	}
+	rsp.DisCard = cards[a.random().Intn(len(cards))]
+	return true
+}
+
+func (a *RandomRobot) Pong(req *proto.PongReq, rsp *proto.PongRsp) bool {
+	rsp.Card, rsp.Ok = req.Card, a.random().Intn(2) == 0
+	return true
+}
+
+func (a *RandomRobot) Eat(req *proto.EatReq, rsp *proto.EatRsp) bool {
+	rsp.Eat = req.Eat[a.random().Intn(len(req.Eat))]
+	rsp.Ok = a.random().Intn(2) == 0
+	return true
+}
+
+func (a *RandomRobot) Gang(req *proto.GangReq, rsp *proto.GangRsp) bool {
+	rsp.Gang = req.Gang[a.random().Intn(len(req.Gang))]
+	rsp.Ok = a.random().Intn(2) == 0
+	return true
+}
@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
+	"server/conf"
+	"server/game/area_manager"
+	"server/proto"
+	"sort"
+	"sync"
+)
+
+// 不走网络, 全机器人的桌子在进程里跑真实的Table.Play, 统计胜率和番型, 用来调番和抓引擎的panic
+
+type SimConfig struct {
+	Tables    int      // 跑多少桌
+	Hands     int      // 每桌打多少局
+	Seed      int64    // 第i桌的种子是Seed+i, 同样的配置结果一样
+	Area      uint16   // 区域, 和CreateTableReq.Area一样
+	PlayerNum int      // 0表示配置里的默认人数
+	Robots    []string // 每个座位的机器人策略, 不够的循环使用
+	Workers   int      // 同时跑几桌, 0表示1
+	Check     bool     // 打开不变量检查, 出错算panic
+}
+
+type SimPanic struct {
+	Seed  int64
+	Hand  int
+	Err   string
+	Stack string
+}
+
+type SimReport struct {
+	Config    SimConfig
+	Hands     int
+	Draws     int // 流局
+	Rounds    int // 所有局的打牌轮数之和
+	Wins      int
+	ZiMo      int
+	SeatWins  []int
+	SeatScore []int          // 每个座位的输赢分之和
+	Patterns  map[string]int // 每个番型命中的次数
+	FanHist   map[int]int    // 每次胡牌的番数分布
+	Panics    []*SimPanic
+}
+
+func newSimReport(config SimConfig) *SimReport {
+	r := new(SimReport)
+	r.Config = config
+	r.SeatWins = make([]int, config.PlayerNum)
+	r.SeatScore = make([]int, config.PlayerNum)
+	r.Patterns = make(map[string]int)
+	r.FanHist = make(map[int]int)
+	return r
+}
+
+func (r *SimReport) merge(other *SimReport) {
+	r.Hands += other.Hands
+	r.Draws += other.Draws
+	r.Rounds += other.Rounds
+	r.Wins += other.Wins
+	r.ZiMo += other.ZiMo
+	for i := range r.SeatWins {
+		r.SeatWins[i] += other.SeatWins[i]
+		r.SeatScore[i] += other.SeatScore[i]
+	}
+	for name, num := range other.Patterns {
+		r.Patterns[name] += num
+	}
+	for fan, num := range other.FanHist {
+		r.FanHist[fan] += num
+	}
+	r.Panics = append(r.Panics, other.Panics...)
+}
+
+// 一局结束之后按胡牌顺序结算: 点炮的人一个人给, 自摸的时候还没胡的人每人给一份
+func (r *SimReport) addHand(t *Table) {
+	r.Hands++
+	r.Rounds += t.round
+	if len(t.win_players) == 0 {
+		r.Draws++
+		return
+	}
+	for k, winner := range t.win_players {
+		pos, _ := t.GetPlayerIndex(winner.uid)
+		r.Wins++
+		r.SeatWins[pos]++
+		r.FanHist[winner.fan]++
+		for _, name := range winner.fan_names {
+			r.Patterns[name]++
+		}
+		if winner.lose_uid != 0 {
+			if lose, err := t.GetPlayerIndex(winner.lose_uid); err == nil {
+				r.SeatScore[lose] -= winner.fan
+				r.SeatScore[pos] += winner.fan
+			}
+			continue
+		}
+		r.ZiMo++
+		for i, player := range t.players {
+			if i == pos || containsPlayer(t.win_players[:k], player) {
+				continue
+			}
+			r.SeatScore[i] -= winner.fan
+			r.SeatScore[pos] += winner.fan
+		}
+	}
+}
+
+func containsPlayer(players []*Player, p *Player) bool {
+	for _, player := range players {
+		if player == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *SimReport) String() string {
+	var buf bytes.Buffer
+	c := r.Config
+	fmt.Fprintf(&buf, "tables:%v, hands/table:%v, seed:%v, area:%v, players:%v, robots:%v\n", c.Tables, c.Hands, c.Seed, c.Area, c.PlayerNum, c.Robots)
+	if r.Hands > 0 {
+		r.writeStats(&buf)
+	}
+	fmt.Fprintf(&buf, "panics:%v\n", len(r.Panics))
+	for _, p := range r.Panics {
+		fmt.Fprintf(&buf, "  seed:%v, hand:%v, %v\n%v", p.Seed, p.Hand, p.Err, p.Stack)
+	}
+	return buf.String()
+}
+
+func (r *SimReport) writeStats(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "hands:%v, wins:%v, 自摸:%v, 流局:%v (%.2f%%), avg rounds:%.2f\n", r.Hands, r.Wins, r.ZiMo, r.Draws,
+		percent(r.Draws, r.Hands), float64(r.Rounds)/float64(r.Hands))
+	buf.WriteString("seat  win rate  score\n")
+	for i := range r.SeatWins {
+		fmt.Fprintf(buf, "%4d  %7.2f%%  %5d\n", i, percent(r.SeatWins[i], r.Hands), r.SeatScore[i])
+	}
+	buf.WriteString("patterns:\n")
+	var names []string
+	for name := range r.Patterns {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if r.Patterns[names[i]] != r.Patterns[names[j]] {
+			return r.Patterns[names[i]] > r.Patterns[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		fmt.Fprintf(buf, "  %v: %v (%.2f%%)\n", name, r.Patterns[name], percent(r.Patterns[name], r.Wins))
+	}
+	buf.WriteString("fan distribution:\n")
+	var fans []int
+	for fan := range r.FanHist {
+		fans = append(fans, fan)
+	}
+	sort.Ints(fans)
+	for _, fan := range fans {
+		fmt.Fprintf(buf, "  %3d fan: %v (%.2f%%)\n", fan, r.FanHist[fan], percent(r.FanHist[fan], r.Wins))
+	}
+}
+
+func percent(num int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(num) * 100 / float64(total)
+}
+
+func (c *SimConfig) check() error {
+	if c.PlayerNum == 0 {
+		c.PlayerNum = conf.Server.Game.DefaultPlayerNum
+	}
+	if c.Workers <= 0 {
+		c.Workers = 1
+	}
+	if len(c.Robots) == 0 {
+		c.Robots = []string{conf.Server.Game.TableRobot}
+	}
+	if c.Tables <= 0 || c.Hands <= 0 {
+		return fmt.Errorf("tables and hands must be positive, got %v, %v", c.Tables, c.Hands)
+	}
+	for _, name := range c.Robots {
+		if _, ok := robotCreators[name]; !ok {
+			return fmt.Errorf("unknown robot %q, want one of %v", name, RobotNames())
+		}
+	}
+	if rule := area_manager.GetArea(c.Area); area_manager.GetRuleId(rule) != c.Area {
+		return fmt.Errorf("unknown area %v", c.Area)
+	} else if !rule.ValidPlayerNum(c.PlayerNum) {
+		return fmt.Errorf("invalid player num %v for area %v", c.PlayerNum, c.Area)
+	}
+	return nil
+}
+
+// 调用之前要先area_manager.Init, 服务器里是game模块的OnInit
+func Simulate(config SimConfig) (*SimReport, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	report := newSimReport(config)
+	seeds := make(chan int64)
+	results := make(chan *SimReport)
+	var wg sync.WaitGroup
+	for i := 0; i < config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seed := range seeds {
+				results <- simulateTable(config, seed)
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < config.Tables; i++ {
+			seeds <- config.Seed + int64(i)
+		}
+		close(seeds)
+		wg.Wait()
+		close(results)
+	}()
+	for result := range results {
+		report.merge(result)
+	}
+	return report, nil
+}
+
+// 模拟的桌子不进Tables和MapUidPlayer, 不会和服务器上的桌子冲突
+func simulateTable(config SimConfig, seed int64) (report *SimReport) {
+	report = newSimReport(config)
+	hand := 0
+	defer func() {
+		if err := recover(); err != nil {
+			report.Panics = append(report.Panics, &SimPanic{Seed: seed, Hand: hand, Err: fmt.Sprint(err), Stack: string(debug.Stack())})
+		}
+	}()
+	t := NewTable(0, proto.CreateTableReq_TableRobot, config.PlayerNum)
+	t.SetRule(area_manager.GetArea(config.Area))
+	t.SetSeed(seed)
+	if config.Check {
+		t.EnableCheck(true)
+	}
+	for i := 0; i < config.PlayerNum; i++ {
+		uid := conf.Server.Game.MinRobotId + uint64(i)
+		player := NewPlayer(NewAgent(uid), uid)
+		player.isRobot = true
+		player.SetTable(t)
+		if err := player.SetRobot(config.Robots[i%len(config.Robots)]); err != nil {
+			panic(err)
+		}
+		player.online = true
+		t.players = append(t.players, player)
+	}
+	for hand = 0; hand < config.Hands; hand++ {
+		t.Clear()
+		t.Play()
+		report.addHand(t)
+	}
+	return report
+}
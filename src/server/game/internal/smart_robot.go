@@ -0,0 +1,307 @@
+package internal
+
+import (
+	"server/proto"
+	"server/utils"
+)
+
+const (
+	// 活端剩下多少张算牌局后期, 开始防守
+	LateWallNum = 20
+)
+
+// 按向听数和进张数打牌的机器人, 听牌之后的进张用规则的听牌分析来算
+// 牌局后期有人副露多的时候避开危险牌, 吃碰之后向听数不变小就不吃碰
+type SmartRobot struct {
+	*BaseRobot
+	defense bool // 后期是否防守
+}
+
+type dropChoice struct {
+	card       int32
+	shanten    int
+	acceptance int
+	danger     int
+}
+
+func NewSmartRobot(p *Player) *SmartRobot {
+	a := new(SmartRobot)
+	a.BaseRobot = NewRobot(p)
+	a.defense = true
+	return a
+}
+
+func (a *SmartRobot) HandlerMsg(req interface{}) (interface{}, error) {
+	return handlerRobotMsg(a, req)
+}
+
+func (a *SmartRobot) Drop(req *proto.DropReq, rsp *proto.DropRsp) bool {
+	cards := utils.Copy(a.player.cards)
+	hun_card := a.player.table.hun_card
+	separate_result := utils.SeparateCards(cards, hun_card)
+	if que := a.player.que; que != 0 && len(separate_result[que]) > 0 {
+		rsp.DisCard = a.safest(separate_result[que])
+		return true
+	}
+	choices := a.dropChoices(cards)
+	if len(choices) == 0 {
+		rsp.DisCard = utils.DropRand(cards, hun_card)
+		return true
+	}
+	rsp.DisCard = a.bestChoice(choices).card
+	return true
+}
+
+func (a *SmartRobot) Pong(req *proto.PongReq, rsp *proto.PongRsp) bool {
+	rsp.Card = req.Card
+	rsp.Ok = a.betterAfterMeld([]int32{req.Card, req.Card})
+	return true
+}
+
+func (a *SmartRobot) Eat(req *proto.EatReq, rsp *proto.EatRsp) bool {
+	rsp.Eat = req.Eat[0]
+	rsp.Ok = false
+	for _, eat := range req.Eat {
+		if a.betterAfterMeld(eat.HandCard) {
+			rsp.Eat = eat
+			rsp.Ok = true
+			return true
+		}
+	}
+	return true
+}
+
+// 杠不改变向听数就杠, 拆了听牌或者搭子就不杠
+func (a *SmartRobot) Gang(req *proto.GangReq, rsp *proto.GangRsp) bool {
+	rsp.Gang = req.Gang[0]
+	rsp.Ok = false
+	hun_card := a.player.table.hun_card
+	meld_num := len(a.player.waves)
+	cards := a.player.cards
+	if utils.IsTingCardNum(len(cards)) {
+		// 明杠, 手上是13张
+		before := utils.Shanten(cards, hun_card, meld_num)
+		for _, gang := range req.Gang {
+			rest := utils.DelCountCard(utils.Copy(cards), gang.Cards[0], 3)
+			if utils.Shanten(rest, hun_card, meld_num+1) <= before {
+				rsp.Gang, rsp.Ok = gang, true
+				return true
+			}
+		}
+		return true
+	}
+	// 暗杠或者补杠, 手上是14张, 和打一张之后的最好向听数比
+	before := a.minDropShanten(cards, meld_num)
+	for _, gang := range req.Gang {
+		rest := utils.Copy(cards)
+		if gang.Type == proto.GangType_AnGang {
+			rest = utils.DelCountCard(rest, gang.Cards[0], 4)
+			meld_num++
+		} else {
+			rest = utils.DelCountCard(rest, gang.Cards[0], 1)
+		}
+		if utils.Shanten(rest, hun_card, meld_num) <= before {
+			rsp.Gang, rsp.Ok = gang, true
+			return true
+		}
+		meld_num = len(a.player.waves)
+	}
+	return true
+}
+
+// 吃碰之后还要打一张, 打完之后的向听数比现在小才吃碰
+func (a *SmartRobot) betterAfterMeld(hand_cards []int32) bool {
+	hun_card := a.player.table.hun_card
+	meld_num := len(a.player.waves)
+	cards := a.player.cards
+	before := utils.Shanten(cards, hun_card, meld_num)
+	rest := utils.Copy(cards)
+	for _, card := range hand_cards {
+		rest = utils.DelCountCard(rest, card, 1)
+	}
+	if len(rest) != len(cards)-len(hand_cards) {
+		return false
+	}
+	return a.minDropShanten(rest, meld_num+1) < before
+}
+
+func (a *SmartRobot) minDropShanten(cards []int32, meld_num int) int {
+	hun_card := a.player.table.hun_card
+	best := 8
+	for _, card := range distinctCards(cards) {
+		if card == hun_card {
+			continue
+		}
+		rest := utils.DelCountCard(utils.Copy(cards), card, 1)
+		if shanten := utils.Shanten(rest, hun_card, meld_num); shanten < best {
+			best = shanten
+		}
+	}
+	return best
+}
+
+func (a *SmartRobot) dropChoices(cards []int32) []*dropChoice {
+	hun_card := a.player.table.hun_card
+	meld_num := len(a.player.waves)
+	visible := a.visibleCards()
+	remain := func(card int32) int {
+		return 4 - visible[card]
+	}
+	var choices []*dropChoice
+	for _, card := range distinctCards(cards) {
+		if card == hun_card {
+			continue
+		}
+		rest := utils.DelCountCard(utils.Copy(cards), card, 1)
+		choice := &dropChoice{card: card, danger: a.danger(card, visible)}
+		choice.shanten = utils.Shanten(rest, hun_card, meld_num)
+		if choice.shanten == 0 {
+			choice.acceptance = a.tingAcceptance(rest, remain)
+		} else {
+			choice.acceptance, _ = utils.Acceptance(rest, hun_card, meld_num, remain)
+		}
+		choices = append(choices, choice)
+	}
+	return choices
+}
+
+// 先比向听数, 再比进张数, 最后比危险度; 后期被威胁的时候离听牌还远就弃胡
+func (a *SmartRobot) bestChoice(choices []*dropChoice) *dropChoice {
+	better := func(x, y *dropChoice) bool {
+		if x.shanten != y.shanten {
+			return x.shanten < y.shanten
+		}
+		if x.acceptance != y.acceptance {
+			return x.acceptance > y.acceptance
+		}
+		return x.danger < y.danger
+	}
+	best := choices[0]
+	for _, choice := range choices[1:] {
+		if better(choice, best) {
+			best = choice
+		}
+	}
+	if !a.defense || !a.isLate() || !a.threatened() || best.shanten <= 0 {
+		return best
+	}
+	max_shanten := best.shanten + 1
+	if best.shanten >= 2 {
+		max_shanten = 8
+	}
+	safest := best
+	for _, choice := range choices {
+		if choice.shanten <= max_shanten && choice.danger < safest.danger {
+			safest = choice
+		}
+	}
+	return safest
+}
+
+// 听牌的时候按规则算能胡哪些牌, 特殊牌型(腾空等)不限牌张, 按最多算
+func (a *SmartRobot) tingAcceptance(cards []int32, remain func(card int32) int) int {
+	ctx := a.player.NewContext()
+	// 换了手牌, 每门的缓存都要重新算
+	ctx.Player.Cards = cards
+	ctx.Player.NeedHun = []int32{4, 4, 4, 4}
+	ctx.Player.NeedHunWithEye = []int32{4, 4, 4, 4}
+	ctx.Player.IsNeedUpdate = []bool{true, true, true, true}
+	_, _, prewin_cards := a.player.table.rule.GetTingCards(ctx)
+	total := 0
+	for card := range prewin_cards {
+		if card < 100 {
+			return 4 * len(utils.AllCardKinds)
+		}
+		if num := remain(card); num > 0 {
+			total += num
+		}
+	}
+	return total
+}
+
+func (a *SmartRobot) safest(cards []int32) int32 {
+	visible := a.visibleCards()
+	safest := cards[0]
+	for _, card := range cards[1:] {
+		if a.danger(card, visible) < a.danger(safest, visible) {
+			safest = card
+		}
+	}
+	return safest
+}
+
+// 自己看得到的牌: 自己的手牌, 所有人打出的牌和吃碰杠
+// 碰和明杠有一张是别人打的, 打牌记录里已经有了, 不重复算
+func (a *SmartRobot) visibleCards() map[int32]int {
+	visible := make(map[int32]int)
+	for _, card := range a.player.cards {
+		visible[card]++
+	}
+	table := a.player.table
+	for _, cards := range table.drop_record {
+		for _, card := range cards {
+			visible[card]++
+		}
+	}
+	for _, player := range table.players {
+		for _, wave := range player.waves {
+			for _, card := range wave.Cards {
+				visible[card]++
+			}
+			if wave.WaveType == proto.Wave_PongWave || wave.WaveType == proto.Wave_GangWave && wave.GangType != proto.GangType_AnGang {
+				visible[wave.Cards[0]]--
+			}
+		}
+	}
+	for card, num := range visible {
+		if num > 4 {
+			visible[card] = 4
+		}
+	}
+	return visible
+}
+
+// 危险度: 中张比幺九和字牌危险, 见得越多越安全
+func (a *SmartRobot) danger(card int32, visible map[int32]int) int {
+	rank := 4
+	if card/100 == 4 {
+		rank = 1
+	} else if point := card % 10; point == 1 || point == 9 {
+		rank = 2
+	} else if point == 2 || point == 8 {
+		rank = 3
+	}
+	unseen := 4 - visible[card]
+	if unseen < 0 {
+		unseen = 0
+	}
+	return rank * unseen
+}
+
+func (a *SmartRobot) isLate() bool {
+	table := a.player.table
+	return len(table.left_cards)-table.rule.DeadWallNum() <= LateWallNum
+}
+
+// 还没胡的对手副露两组以上就算有威胁
+func (a *SmartRobot) threatened() bool {
+	for _, player := range a.player.table.players {
+		if player.uid == a.player.uid || player.IsWin() {
+			continue
+		}
+		if len(player.waves) >= 2 {
+			return true
+		}
+	}
+	return false
+}
+
+func distinctCards(cards []int32) []int32 {
+	var result []int32
+	for _, card := range cards {
+		if !utils.Contain(result, card) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
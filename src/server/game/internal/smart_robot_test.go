@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"server/proto"
+	"testing"
+)
+
+func TestSmartRobotDrop(t *testing.T) {
+	initArea()
+	cases := []struct {
+		area uint16
+		hand string
+		que  int32
+		want int32
+	}{
+		// 打孤张, 留下两面听
+		{1, "12356m4569p78911s", 0, 209},
+		// 只有打9万能听牌
+		{1, "123469m456p78911s", 0, 109},
+		// 手里有缺门先打缺门, 幺九比中张安全
+		{1, "15m456p789s112233s", 1, 101},
+		{0, "123m56m456p789s11z4z", 0, 404},
+	}
+	for _, c := range cases {
+		robot := NewSmartRobot(newTestPlayer(c.area, c.hand, c.que))
+		rsp := new(proto.DropRsp)
+		robot.Drop(new(proto.DropReq), rsp)
+		if rsp.DisCard != c.want {
+			t.Errorf("area:%v, %v, que:%v, drop %v, want %v", c.area, c.hand, c.que, rsp.DisCard, c.want)
+		}
+	}
+}
+
+// 吃碰之后打一张, 向听数变小才吃碰
+func TestSmartRobotMeld(t *testing.T) {
+	initArea()
+	pongs := []struct {
+		hand string
+		card int32
+		want bool
+	}{
+		{"11m456p789s12357z", 101, true},
+		{"1123m456p789s115z", 101, true},
+		// 已经听牌了, 碰了还是听牌
+		{"123m456p789s1122z", 401, false},
+	}
+	for _, c := range pongs {
+		robot := NewSmartRobot(newTestPlayer(0, c.hand, 0))
+		rsp := new(proto.PongRsp)
+		robot.Pong(&proto.PongReq{Card: c.card}, rsp)
+		if rsp.Ok != c.want || rsp.Card != c.card {
+			t.Errorf("%v, pong %v: ok %v, want %v", c.hand, c.card, rsp.Ok, c.want)
+		}
+	}
+	eats := []struct {
+		hand string
+		eat  []*proto.Eat
+		want bool
+	}{
+		{"13m456p789s12357z", []*proto.Eat{{HandCard: []int32{101, 103}, WaveCard: []int32{101, 102, 103}}}, true},
+		{"123m456p789s1122z", []*proto.Eat{{HandCard: []int32{102, 103}, WaveCard: []int32{102, 103, 104}}}, false},
+	}
+	for _, c := range eats {
+		robot := NewSmartRobot(newTestPlayer(0, c.hand, 0))
+		rsp := new(proto.EatRsp)
+		robot.Eat(&proto.EatReq{Eat: c.eat}, rsp)
+		if rsp.Ok != c.want || rsp.Eat != c.eat[0] {
+			t.Errorf("%v, eat %v: ok %v, want %v", c.hand, c.eat[0].WaveCard, rsp.Ok, c.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package gamedata
+
+// 番型表 gamedata/Fan.txt, 同一个番型可以按区域写几行, 后面的覆盖前面的
+type Fan struct {
+	Id        string
+	Name      string
+	Value     int
+	Exclusive bool     // 独立计番
+	Exclude   []string // 命中之后不再计算的番型
+	Areas     []int    // 启用的区域, 空表示所有区域
+}
+
+func (m *Fan) Enabled(area int) bool {
+	if len(m.Areas) == 0 {
+		return true
+	}
+	for _, a := range m.Areas {
+		if a == area {
+			return true
+		}
+	}
+	return false
+}
+
+func LoadFans() ([]*Fan, error) {
+	rf, err := loadRf(Fan{})
+	if err != nil {
+		return nil, err
+	}
+	var fans []*Fan
+	for i := 0; i < rf.NumRecord(); i++ {
+		fans = append(fans, rf.Record(i).(*Fan))
+	}
+	return fans, nil
+}
@@ -1,21 +1,34 @@
 package gamedata
 
 import (
+	"fmt"
 	"github.com/jxbdlut/leaf/log"
 	"github.com/jxbdlut/leaf/recordfile"
+	"path"
 	"reflect"
+	"server/conf"
 )
 
 func readRf(st interface{}) *recordfile.RecordFile {
-	rf, err := recordfile.New(st)
+	rf, err := loadRf(st)
 	if err != nil {
 		log.Fatal("%v", err)
 	}
+
+	return rf
+}
+
+// 和readRf一样, 出错的时候返回错误, 用于运行时重新加载
+func loadRf(st interface{}) (*recordfile.RecordFile, error) {
+	rf, err := recordfile.New(st)
+	if err != nil {
+		return nil, err
+	}
 	fn := reflect.TypeOf(st).Name() + ".txt"
-	err = rf.Read("gamedata/" + fn)
+	err = rf.Read(path.Join(conf.Server.Persistence.GameDataPath, fn))
 	if err != nil {
-		log.Fatal("%v: %v", fn, err)
+		return nil, fmt.Errorf("%v: %v", fn, err)
 	}
 
-	return rf
+	return rf, nil
 }
@@ -2,28 +2,74 @@ package internal
 
 import (
 	"github.com/jxbdlut/leaf/gate"
+	"github.com/jxbdlut/leaf/network"
 	"server/conf"
 	"server/game"
 	"server/proto"
+	"sync"
 )
 
+// tcp和websocket各起一个gate, 这样每个监听可以用自己的编码
 type Module struct {
-	*gate.Gate
+	gates []*gate.Gate
 }
 
 func (m *Module) OnInit() {
-	m.Gate = &gate.Gate{
+	if conf.Server.TCPAddr != "" {
+		g := newGate(conf.Server.TCPProcessor)
+		g.TCPAddr = conf.Server.TCPAddr
+		g.LenMsgLen = conf.LenMsgLen
+		g.LittleEndian = conf.LittleEndian
+		m.gates = append(m.gates, g)
+	}
+	if conf.Server.WSAddr != "" {
+		g := newGate(conf.Server.WSProcessor)
+		g.WSAddr = conf.Server.WSAddr
+		g.HTTPTimeout = conf.HTTPTimeout
+		g.CertFile = conf.Server.CertFile
+		g.KeyFile = conf.Server.KeyFile
+		m.gates = append(m.gates, g)
+	}
+}
+
+func (m *Module) Run(closeSig chan bool) {
+	var wg sync.WaitGroup
+	var sigs []chan bool
+	for _, g := range m.gates {
+		sig := make(chan bool, 1)
+		sigs = append(sigs, sig)
+		wg.Add(1)
+		go func(g *gate.Gate) {
+			g.Run(sig)
+			wg.Done()
+		}(g)
+	}
+	<-closeSig
+	for _, sig := range sigs {
+		sig <- true
+	}
+	wg.Wait()
+}
+
+func (m *Module) OnDestroy() {
+	for _, g := range m.gates {
+		g.OnDestroy()
+	}
+}
+
+func newGate(processor string) *gate.Gate {
+	return &gate.Gate{
 		MaxConnNum:      conf.Server.MaxConnNum,
 		PendingWriteNum: conf.PendingWriteNum,
 		MaxMsgLen:       conf.MaxMsgLen,
-		WSAddr:          conf.Server.WSAddr,
-		HTTPTimeout:     conf.HTTPTimeout,
-		CertFile:        conf.Server.CertFile,
-		KeyFile:         conf.Server.KeyFile,
-		TCPAddr:         conf.Server.TCPAddr,
-		LenMsgLen:       conf.LenMsgLen,
-		LittleEndian:    conf.LittleEndian,
-		Processor:       proto.Processor,
+		Processor:       getProcessor(processor),
 		AgentChanRPC:    game.ChanRPC,
 	}
 }
+
+func getProcessor(name string) network.Processor {
+	if name == conf.ProcessorJSON {
+		return proto.JSONProcessor
+	}
+	return proto.Processor
+}
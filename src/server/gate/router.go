@@ -1,15 +1,25 @@
 package gate
 
 import (
+	"github.com/jxbdlut/leaf/chanrpc"
 	"server/game"
 	"server/login"
 	"server/proto"
 )
 
 func init() {
-	proto.Processor.SetRouter(&proto.LoginReq{}, login.ChanRPC)
-	proto.Processor.SetRouter(&proto.CreateTableReq{}, game.ChanRPC)
-	proto.Processor.SetRouter(&proto.JoinTableReq{}, game.ChanRPC)
-	proto.Processor.SetRouter(&proto.OperatRsp{}, game.ChanRPC)
-	proto.Processor.SetRouter(&proto.TableOperatRsp{}, game.ChanRPC)
+	route(&proto.LoginReq{}, login.ChanRPC)
+	route(&proto.CreateTableReq{}, game.ChanRPC)
+	route(&proto.JoinTableReq{}, game.ChanRPC)
+	route(&proto.OperatRsp{}, game.ChanRPC)
+	route(&proto.TableOperatRsp{}, game.ChanRPC)
+	route(&proto.HeartbeatReq{}, game.ChanRPC)
+	route(&proto.TrusteeReq{}, game.ChanRPC)
+	route(&proto.GetAreaReq{}, game.ChanRPC)
+}
+
+// tcp和websocket可以用不同的编码, 路由要两边都设置
+func route(msg interface{}, server *chanrpc.Server) {
+	proto.Processor.SetRouter(msg, server)
+	proto.JSONProcessor.SetRouter(msg, server)
 }
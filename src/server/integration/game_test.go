@@ -0,0 +1,355 @@
+package integration
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"server/proto"
+	"server/sdk"
+	"server/utils"
+)
+
+// 固定的打法, 同一副牌每次打出来都一样: 能胡就胡, 吃碰杠都过, 出牌打摸到的那张.
+// single的时候先打单张, 手牌会变好, 容易有人胡. 不打单张的时候除了庄家第一张只看摸到的牌,
+// 和服务器超时替人打的那张一样, 重连之后手牌不全也打得一样
+type scripted struct {
+	single bool
+	hook   func(t *sdk.Table, req *proto.OperatReq) // 做决定之前调用, 在读消息的goroutine里
+}
+
+func (d *scripted) TableOperat(t *sdk.Table, req *proto.TableOperatReq) bool {
+	return true
+}
+
+func (d *scripted) Operat(t *sdk.Table, req *proto.OperatReq) *proto.OperatRsp {
+	if d.hook != nil {
+		d.hook(t, req)
+	}
+	switch {
+	case req.Type&proto.OperatType_HuOperat != 0:
+		rsp := sdk.Pass(req)
+		rsp.HuRsp.Ok = true
+		return rsp
+	case req.Type&proto.OperatType_DropOperat != 0:
+		rsp := proto.NewOperatRsp()
+		rsp.Type = proto.OperatType_DropOperat
+		rsp.DropRsp.DisCard = t.DrawCard
+		if d.single {
+			if card := utils.DropSingle(utils.SeparateCards(t.Cards, t.HunCard)); card != 0 {
+				rsp.DropRsp.DisCard = card
+			}
+		}
+		if rsp.DropRsp.DisCard == 0 && len(t.Cards) > 0 {
+			rsp.DropRsp.DisCard = t.Cards[len(t.Cards)-1]
+		}
+		return rsp
+	}
+	return nil
+}
+
+var operatNames = []struct {
+	t    proto.OperatType
+	name string
+}{
+	{proto.OperatType_HuOperat, "hu"},
+	{proto.OperatType_GangOperat, "gang"},
+	{proto.OperatType_PongOperat, "pong"},
+	{proto.OperatType_EatOperat, "eat"},
+	{proto.OperatType_DropOperat, "drop"},
+	{proto.OperatType_DingQueOperat, "dingque"},
+}
+
+func operatName(t proto.OperatType) string {
+	var names []string
+	for _, o := range operatNames {
+		if t&o.t != 0 {
+			names = append(names, o.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// 一条推送写成一行, uid换成座位号, 不同的uid打同一副牌写出来一样.
+// public表示同桌每个人都收到, TableOperatMsg谁先回不一定, 不记
+func describe(msg interface{}, uids []uint64) (line string, public bool) {
+	pos := func(uid uint64) int {
+		for i, u := range uids {
+			if u == uid {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	switch m := msg.(type) {
+	case *proto.UserJoinTableMsg:
+		return fmt.Sprintf("join %v", len(m.Seats)), false
+	case *proto.TableOperatReq:
+		return fmt.Sprintf("ask %v", m.Type), false
+	case *proto.PlayerStatusMsg:
+		return fmt.Sprintf("%v %v", pos(m.Uid), proto.PlayerStatusMap[m.Status]), false
+	case *proto.DiceMsg:
+		return fmt.Sprintf("dice dealer:%v", pos(m.Dealer)), true
+	case *proto.OperatReq:
+		switch {
+		case m.Type&proto.OperatType_DealOperat != 0:
+			return "deal " + utils.CardsNotation(m.DealReq.Cards), false
+		case m.Type&proto.OperatType_DrawOperat != 0:
+			return "draw " + utils.CardNotation(m.DrawReq.Card), false
+		}
+		return "req " + operatName(m.Type), false
+	case *proto.OperatMsg:
+		switch {
+		case m.Type&proto.OperatType_HuOperat != 0:
+			return fmt.Sprintf("%v hu %v ok:%v", pos(m.Uid), utils.CardNotation(m.GetHu().GetCard()), m.GetHu().GetOk()), true
+		case m.Type&proto.OperatType_GangOperat != 0:
+			return fmt.Sprintf("%v gang %v ok:%v", pos(m.Uid), utils.CardsNotation(m.GetGang().GetGang().GetCards()), m.GetGang().GetOk()), true
+		case m.Type&proto.OperatType_DropOperat != 0:
+			return fmt.Sprintf("%v drop %v", pos(m.Uid), utils.CardNotation(m.GetDrop().GetDisCard())), true
+		}
+		return fmt.Sprintf("%v %v", pos(m.Uid), operatName(m.Type)), true
+	}
+	return "", false
+}
+
+func transcript(s *seat, uids []uint64, public bool) []string {
+	var lines []string
+	for _, msg := range s.messages() {
+		line, shared := describe(msg, uids)
+		if line == "" || public && !shared {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func uidsOf(seats []*seat) []uint64 {
+	var uids []uint64
+	for _, s := range seats {
+		uids = append(uids, s.Uid)
+	}
+	return uids
+}
+
+// 第一处不一样的地方
+func diff(got, want []string) string {
+	for i := 0; i < len(got) || i < len(want); i++ {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g != w {
+			return fmt.Sprintf("line %v: got %q, want %q (%v lines, want %v)", i, g, w, len(got), len(want))
+		}
+	}
+	return ""
+}
+
+// 四个人按同样的打法打完一局, 返回大家都看到的那部分
+func playHand(t *testing.T, base uint64, single bool) []string {
+	seats := newTable(t, base, &scripted{single: single}, &scripted{single: single}, &scripted{single: single}, &scripted{single: single})
+	waitClosed(t, seats...)
+	return transcript(seats[0], uidsOf(seats), true)
+}
+
+func TestPlayHand(t *testing.T) {
+	seats := newTable(t, 1001, &scripted{single: true}, &scripted{single: true}, &scripted{single: true}, &scripted{single: true})
+	uids := uidsOf(seats)
+	waitClosed(t, seats...)
+
+	want := transcript(seats[0], uids, true)
+	for _, s := range seats[1:] {
+		if d := diff(transcript(s, uids, true), want); d != "" {
+			t.Errorf("uid:%v, broadcasts differ from uid:%v, %v", s.Uid, uids[0], d)
+		}
+	}
+
+	// 每个人自己收到的: 入座, 问开始, 掷骰子, 发牌, 之后是打牌, 只打一局
+	for _, s := range seats {
+		lines := transcript(s, uids, false)
+		for len(lines) > 0 && strings.HasPrefix(lines[0], "join ") {
+			lines = lines[1:]
+		}
+		if len(lines) < 4 || lines[0] != "ask TableStart" || lines[1] != "dice dealer:1" || !strings.HasPrefix(lines[2], "deal ") {
+			t.Fatalf("uid:%v, hand begins with %q", s.Uid, lines[:4])
+		}
+		for _, line := range lines[3:] {
+			if strings.HasPrefix(line, "ask ") || strings.HasPrefix(line, "dice ") || strings.HasPrefix(line, "deal ") {
+				t.Errorf("uid:%v, %q after the hand started", s.Uid, line)
+			}
+		}
+	}
+
+	// 结果: 这个种子有一个人胡, 胡牌是最后一条
+	var hu []string
+	for _, line := range want {
+		if strings.Contains(line, " hu ") {
+			hu = append(hu, line)
+		}
+	}
+	if len(hu) != 1 || want[len(want)-1] != hu[0] {
+		t.Fatalf("hu %q, last broadcast %q", hu, want[len(want)-1])
+	}
+	var winner uint64
+	fmt.Sscanf(hu[0], "%d", &winner)
+	winner = uids[winner-1]
+	t.Logf("%v broadcasts, %v", len(want), hu[0])
+
+	// 本地镜像: 每家的牌河大家看到的一样, 都知道谁胡了; 没吃碰杠手牌13张, 自摸的14张
+	var want_drops string
+	for i, s := range seats {
+		s.Table.Lock()
+		var drops []string
+		for _, uid := range uids {
+			drops = append(drops, utils.CardsNotation(s.Table.Seat(uid).Drops))
+		}
+		n, win, winner_seen := len(s.Table.Cards), s.Table.Me().Win, s.Table.Seat(winner).Win
+		s.Table.Unlock()
+		if !winner_seen {
+			t.Errorf("uid:%v, does not see uid:%v win", s.Uid, winner)
+		}
+		if i == 0 {
+			want_drops = strings.Join(drops, " ")
+		} else if got := strings.Join(drops, " "); got != want_drops {
+			t.Errorf("uid:%v, drops %v, uid:%v sees %v", s.Uid, got, uids[0], want_drops)
+		}
+		if n != 13 && !(n == 14 && win) {
+			t.Errorf("uid:%v, %v cards in hand, win:%v", s.Uid, n, win)
+		}
+	}
+
+	// 同一个种子换一批人再打, 牌局一模一样
+	if d := diff(playHand(t, 1011, true), want); d != "" {
+		t.Errorf("same seed, different hand: %v", d)
+	}
+}
+
+// 打到一半断线, 同桌看到掉线; 同一个uid重新登录接着打, 打完的牌局和没断过一样
+func TestReconnect(t *testing.T) {
+	want := playHand(t, 1101, false)
+
+	var holding int32
+	hold := make(chan struct{})
+	disconnect := make(chan struct{})
+	drops := 0
+	// 第一个人在断线重连完之前不回, 桌子停在他这里, 断线的人不会被问到
+	first := &scripted{hook: func(table *sdk.Table, req *proto.OperatReq) {
+		if atomic.LoadInt32(&holding) == 1 {
+			<-hold
+		}
+	}}
+	last := &scripted{hook: func(table *sdk.Table, req *proto.OperatReq) {
+		if req.Type&proto.OperatType_DropOperat == 0 {
+			return
+		}
+		if drops++; drops == 2 {
+			atomic.StoreInt32(&holding, 1)
+			close(disconnect)
+		}
+	}}
+	seats := newTable(t, 1111, first, &scripted{}, &scripted{}, last)
+	uids := uidsOf(seats)
+	select {
+	case <-disconnect:
+	case <-time.After(handTimeout):
+		t.Fatalf("uid:%v, no second drop", uids[3])
+	}
+
+	seats[3].Close()
+	seats[1].waitStatus(t, uids[3], proto.PlayerStatus_StatusOffline)
+	again := dial(t, uids[3], &scripted{})
+	need_recover, err := again.Login()
+	if err != nil || !need_recover {
+		t.Fatalf("uid:%v, login again: need_recover:%v, err:%v", uids[3], need_recover, err)
+	}
+	seats[1].waitStatus(t, uids[3], proto.PlayerStatus_StatusReconnect)
+	close(hold)
+	waitClosed(t, seats[0], seats[1], seats[2], again)
+
+	// 重连之后的请求发到新连接
+	asked := 0
+	for _, msg := range again.messages() {
+		if req, ok := msg.(*proto.OperatReq); ok && req.Type&proto.OperatType_DropOperat != 0 {
+			asked++
+		}
+	}
+	if asked == 0 {
+		t.Errorf("uid:%v, no drop request after reconnect", uids[3])
+	}
+	for _, s := range seats[:3] {
+		if d := diff(transcript(s, uids, true), want); d != "" {
+			t.Errorf("uid:%v, hand differs from the one without disconnect: %v", s.Uid, d)
+		}
+	}
+}
+
+// 超时不回被托管, 自己和同桌都收到托管; 晚到的回复不影响服务器, 取消托管之后接着打完
+func TestOperatTimeout(t *testing.T) {
+	want := playHand(t, 1201, false)
+
+	uids := []uint64{1211, 1212, 1213, 1214}
+	hold := make(chan struct{})
+	slow := true
+	// 第一个人看到最后一个人托管之后不回, 等他取消托管
+	first := &scripted{hook: func(table *sdk.Table, req *proto.OperatReq) {
+		if table.Seat(uids[3]).Status == proto.PlayerStatus_StatusTrustee {
+			<-hold
+		}
+	}}
+	last := &scripted{hook: func(table *sdk.Table, req *proto.OperatReq) {
+		if req.Type&proto.OperatType_DropOperat != 0 && slow {
+			slow = false
+			time.Sleep(operatTimeout*time.Second + 500*time.Millisecond)
+		}
+	}}
+	seats := newTable(t, uids[0], first, &scripted{}, &scripted{}, last)
+
+	for _, s := range seats {
+		s.waitStatus(t, uids[3], proto.PlayerStatus_StatusTrustee)
+	}
+	if err := seats[3].Trustee(false); err != nil {
+		t.Fatalf("uid:%v, cancel trustee: %v", uids[3], err)
+	}
+	seats[1].waitStatus(t, uids[3], proto.PlayerStatus_StatusOnline)
+	close(hold)
+	waitClosed(t, seats...)
+
+	// 超时那张服务器替他打的就是摸的那张, 整局和没超时一样
+	for _, s := range seats {
+		if d := diff(transcript(s, uids, true), want); d != "" {
+			t.Errorf("uid:%v, hand differs from the one without timeout: %v", s.Uid, d)
+		}
+	}
+}
+
+// 不发心跳, 一局打得比空闲超时还久, 只要一直在回操作就不会被断开
+func TestNoHeartbeat(t *testing.T) {
+	want := playHand(t, 1301, false)
+
+	uids := []uint64{1311, 1312, 1313, 1314}
+	var deciders []sdk.Decider
+	for range uids {
+		deciders = append(deciders, &scripted{hook: func(table *sdk.Table, req *proto.OperatReq) {
+			if req.Type&proto.OperatType_DropOperat != 0 {
+				time.Sleep(100 * time.Millisecond)
+			}
+		}})
+	}
+	start := time.Now()
+	seats := newTable(t, uids[0], deciders...)
+	waitClosed(t, seats...)
+	if elapsed := time.Since(start); elapsed < idleTimeout*time.Second {
+		t.Fatalf("hand over in %v, not longer than the idle timeout %vs", elapsed, idleTimeout)
+	}
+	for _, s := range seats {
+		if d := diff(transcript(s, uids, true), want); d != "" {
+			t.Errorf("uid:%v, hand differs from the one with quick replies: %v", s.Uid, d)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jxbdlut/leaf/log"
+	"github.com/jxbdlut/leaf/module"
+	"server/conf"
+	"server/game"
+	"server/gate"
+	"server/login"
+	"server/proto"
+	"server/sdk"
+)
+
+// 端到端测试: 同一个进程里起game/gate/login三个模块, 四个sdk客户端走tcp连上来打一局.
+// 牌墙用固定的种子, 客户端按固定的打法, 同样的种子每次打出来的牌局都一样
+
+const (
+	wallSeed      = 7 // 这副牌打到最后有人胡
+	operatTimeout = 2 // 秒, 超时的测试要等这么久
+	idleTimeout   = 4 // 秒, 这么久没有消息就断开, 测试里的客户端都不发心跳
+	waitTimeout   = 10 * time.Second
+	handTimeout   = time.Minute
+)
+
+var addr string
+
+func TestMain(m *testing.M) {
+	if err := startServer(); err != nil {
+		fmt.Fprintf(os.Stderr, "start server: %v\n", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	module.Destroy()
+	os.Exit(code)
+}
+
+// 不读conf/server.json, 日志打到标准输出, 监听随机端口, 番型表用仓库里的bin/gamedata.
+// 日志默认只打错误, 要看过程设MAHJONG_LOGLEVEL=debug
+func startServer() error {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	addr = l.Addr().String()
+	l.Close()
+
+	conf.Server.LogLevel = "error"
+	if level := os.Getenv(conf.EnvPrefix + "_LOGLEVEL"); level != "" {
+		conf.Server.LogLevel = level
+	}
+	conf.Server.TCPAddr = addr
+	conf.Server.WSAddr = ""
+	conf.Server.Game.OperatTimeout = operatTimeout
+	conf.Server.HeartbeatTimeout = idleTimeout
+	conf.Server.Game.NormalHands = 1
+	conf.Server.Game.WallSeed = wallSeed
+	conf.Server.Persistence.GameDataPath = filepath.Join("..", "..", "..", "bin", "gamedata")
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+	logger, err := log.New(conf.Server.LogLevel, "", conf.LogFlag)
+	if err != nil {
+		return err
+	}
+	log.Export(logger)
+
+	module.Register(game.Module)
+	module.Register(gate.Module)
+	module.Register(login.Module)
+	module.Init()
+
+	// gate在自己的goroutine里监听, 连得上才算起来了
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 一个座位: sdk客户端加上按顺序记下来的推送
+type seat struct {
+	*sdk.Client
+	mutex  sync.Mutex
+	msgs   []interface{}
+	closed chan struct{}
+}
+
+func dial(t *testing.T, uid uint64, decider sdk.Decider) *seat {
+	c, err := sdk.Dial(addr, uid, sdk.Options{Name: fmt.Sprintf("p%v", uid), Events: 256, Decider: decider})
+	if err != nil {
+		t.Fatalf("uid:%v, dial: %v", uid, err)
+	}
+	s := &seat{Client: c, closed: make(chan struct{})}
+	go s.collect()
+	return s
+}
+
+func (s *seat) collect() {
+	for ev := range s.Events() {
+		s.mutex.Lock()
+		s.msgs = append(s.msgs, ev.Msg)
+		s.mutex.Unlock()
+	}
+	close(s.closed)
+}
+
+func (s *seat) messages() []interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]interface{}(nil), s.msgs...)
+}
+
+// 等到收到一条满足条件的推送
+func (s *seat) wait(t *testing.T, what string, match func(msg interface{}) bool) {
+	deadline := time.Now().Add(waitTimeout)
+	for time.Now().Before(deadline) {
+		for _, msg := range s.messages() {
+			if match(msg) {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("uid:%v, no %v in %v", s.Uid, what, waitTimeout)
+}
+
+func (s *seat) waitStatus(t *testing.T, uid uint64, status proto.PlayerStatus) {
+	s.wait(t, fmt.Sprintf("uid:%v %v", uid, proto.PlayerStatusMap[status]), func(msg interface{}) bool {
+		m, ok := msg.(*proto.PlayerStatusMsg)
+		return ok && m.Uid == uid && m.Status == status
+	})
+}
+
+// 打完一局服务器断开所有连接
+func waitClosed(t *testing.T, seats ...*seat) {
+	timeout := time.After(handTimeout)
+	for _, s := range seats {
+		select {
+		case <-s.closed:
+		case <-timeout:
+			t.Fatalf("uid:%v, hand not over in %v", s.Uid, handTimeout)
+		}
+	}
+}
+
+// uid从base开始连四个, 第一个建桌其他人加入, 座位和deciders的顺序一样
+func newTable(t *testing.T, base uint64, deciders ...sdk.Decider) []*seat {
+	var seats []*seat
+	for i, decider := range deciders {
+		s := dial(t, base+uint64(i), decider)
+		if _, err := s.Login(); err != nil {
+			t.Fatalf("uid:%v, login: %v", s.Uid, err)
+		}
+		seats = append(seats, s)
+	}
+	tid, err := seats[0].CreateTable(&proto.CreateTableReq{Type: int32(proto.CreateTableReq_TableNomal), PlayerNum: int32(len(seats))})
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	for _, s := range seats[1:] {
+		if _, err := s.JoinTable(tid); err != nil {
+			t.Fatalf("uid:%v, join table %v: %v", s.Uid, tid, err)
+		}
+	}
+	return seats
+}
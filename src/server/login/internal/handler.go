@@ -3,6 +3,7 @@ package internal
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"fmt"
 	"github.com/jxbdlut/leaf/gate"
 	"github.com/jxbdlut/leaf/log"
 	"reflect"
@@ -27,6 +28,22 @@ func handleLogin(args []interface{}) {
 	seq := args[2].(uint32)
 
 	log.Debug("login:%v, seq:%v", req, seq)
+	if req.Version < proto.MinVersion {
+		log.Error("uid:%v, unsupported protocol version:%v", req.Uid, req.Version)
+		a.Replay(&proto.LoginRsp{
+			ErrCode:    -2,
+			ErrMsg:     fmt.Sprintf("protocol version %v not supported, need %v-%v", req.Version, proto.MinVersion, proto.CurVersion),
+			MinVersion: proto.MinVersion,
+			MaxVersion: proto.CurVersion,
+		}, seq)
+		a.Close()
+		return
+	}
+	// 客户端比服务器新的时候按服务器的版本来
+	version := req.Version
+	if version > proto.CurVersion {
+		version = proto.CurVersion
+	}
 	md5Ctx := md5.New()
 	md5Ctx.Write([]byte(strconv.FormatUint(req.Uid, 10)))
 	cipherStr := md5Ctx.Sum(nil)
@@ -35,18 +52,17 @@ func handleLogin(args []interface{}) {
 		a.SetUserData(&userdata.UserData{
 			Uid: req.Uid,
 		})
-		need_recover := false
-		if _, ok := game.MapUidPlayer[req.Uid]; ok {
-			need_recover = true
-		}
-		player, ok := game.MapUidPlayer[req.Uid]
-		if ok {
+		player, need_recover := game.FindPlayer(req.Uid)
+		if need_recover {
 			player.SetAgent(a)
 		}
 		a.Replay(&proto.LoginRsp{
 			ErrCode:     0,
 			ErrMsg:      "login success",
 			NeedRecover: need_recover,
+			Version:     version,
+			MinVersion:  proto.MinVersion,
+			MaxVersion:  proto.CurVersion,
 		}, seq)
 	} else {
 		a.Replay(&proto.LoginRsp{
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"flag"
 	"github.com/jxbdlut/leaf"
 	lconf "github.com/jxbdlut/leaf/conf"
+	"github.com/jxbdlut/leaf/log"
 	"server/conf"
 	"server/game"
 	"server/gate"
 	"server/login"
+	"server/utils"
 )
 
+var configPath = flag.String("config", "conf/server.json", "server config file")
+
 func main() {
+	flag.Parse()
+	if err := conf.Load(*configPath); err != nil {
+		log.Fatal("load config %v: %v", *configPath, err)
+	}
+	log.Release("config %v:\n%v", *configPath, conf.String())
+
 	lconf.LogLevel = conf.Server.LogLevel
 	lconf.LogPath = conf.Server.LogPath
 	lconf.LogFlag = conf.LogFlag
 	lconf.ConsolePort = conf.Server.ConsolePort
 	lconf.ProfilePath = conf.Server.ProfilePath
+	utils.LogCardStyle = conf.Server.LogCardStyle
 
 	leaf.Run(
 		game.Module,
@@ -0,0 +1,209 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	proto1 "github.com/golang/protobuf/proto"
+	"github.com/jxbdlut/leaf/chanrpc"
+	"reflect"
+)
+
+// 协议版本, 不兼容的修改才加大版本号, 低于MinVersion的客户端登录的时候会被拒绝
+const (
+	MinVersion int32 = 1
+	CurVersion int32 = 1
+)
+
+// 信封里body的编码
+const (
+	CodecProtobuf = iota
+	CodecJSON
+)
+
+type MsgHandler func([]interface{})
+
+type cmdInfo struct {
+	cmd     Cmd
+	module  string
+	msgType reflect.Type
+}
+
+var (
+	cmdInfos = make(map[Cmd]*cmdInfo)
+	cmdIds   = make(map[reflect.Type]Cmd)
+)
+
+// 命令号写死在proto_mahjong.proto里, 和注册顺序无关
+func RegisterCmd(cmd Cmd, module string, msg interface{}) {
+	msgType := reflect.TypeOf(msg)
+	if _, ok := cmdInfos[cmd]; ok {
+		panic(fmt.Sprintf("cmd %v has areadly register", cmd))
+	}
+	if _, ok := cmdIds[msgType]; ok {
+		panic(fmt.Sprintf("message %v has areadly register", msgType))
+	}
+	cmdInfos[cmd] = &cmdInfo{cmd: cmd, module: module, msgType: msgType}
+	cmdIds[msgType] = cmd
+}
+
+func GetCmd(msg interface{}) (Cmd, bool) {
+	cmd, ok := cmdIds[reflect.TypeOf(msg)]
+	return cmd, ok
+}
+
+// json编码的信封, 上下行共用, body直接是消息的json
+type jsonEnvelope struct {
+	Version int32           `json:"version,omitempty"`
+	Uid     uint64          `json:"uid,omitempty"`
+	ErrCode int32           `json:"err_code,omitempty"`
+	ErrMsg  string          `json:"err_msg,omitempty"`
+	Seq     uint32          `json:"seq"`
+	Module  string          `json:"module,omitempty"`
+	Cmd     uint32          `json:"cmd"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+// 消息都包在mahjongReq/mahjongRsp里, 用命令号区分
+// 服务器收mahjongReq发mahjongRsp, 客户端反过来
+type EnvelopeProcessor struct {
+	codec    int
+	server   bool
+	Uid      uint64 // 客户端填在mahjongReq里的uid
+	Version  int32  // 客户端填在mahjongReq里的版本, 登录协商之后修改
+	routers  map[Cmd]*chanrpc.Server
+	handlers map[Cmd]MsgHandler
+}
+
+func NewEnvelopeProcessor(codec int, server bool) *EnvelopeProcessor {
+	p := new(EnvelopeProcessor)
+	p.codec = codec
+	p.server = server
+	p.Version = CurVersion
+	p.routers = make(map[Cmd]*chanrpc.Server)
+	p.handlers = make(map[Cmd]MsgHandler)
+	return p
+}
+
+func (p *EnvelopeProcessor) SetRouter(msg interface{}, msgRouter *chanrpc.Server) {
+	cmd, ok := GetCmd(msg)
+	if !ok {
+		panic(fmt.Sprintf("message %v not registered", reflect.TypeOf(msg)))
+	}
+	p.routers[cmd] = msgRouter
+}
+
+func (p *EnvelopeProcessor) SetHandler(msg interface{}, msgHandler MsgHandler) {
+	cmd, ok := GetCmd(msg)
+	if !ok {
+		panic(fmt.Sprintf("message %v not registered", reflect.TypeOf(msg)))
+	}
+	p.handlers[cmd] = msgHandler
+}
+
+func (p *EnvelopeProcessor) Route(msg interface{}, seq uint32, userData interface{}) error {
+	cmd, ok := GetCmd(msg)
+	if !ok {
+		return fmt.Errorf("message %v not registered", reflect.TypeOf(msg))
+	}
+	if handler, ok := p.handlers[cmd]; ok {
+		handler([]interface{}{msg, userData, seq})
+	}
+	if router, ok := p.routers[cmd]; ok {
+		router.Go(reflect.TypeOf(msg), msg, userData, seq)
+	}
+	return nil
+}
+
+func (p *EnvelopeProcessor) Unmarshal(data []byte) (interface{}, uint32, error) {
+	var env jsonEnvelope
+	var body []byte
+	if p.codec == CodecJSON {
+		if err := json.Unmarshal(data, &env); err != nil {
+			return nil, 0, err
+		}
+		body = env.Body
+	} else if p.server {
+		req := &MahjongReq{}
+		if err := proto1.Unmarshal(data, req); err != nil {
+			return nil, 0, err
+		}
+		env.Version, env.Uid, env.Seq, env.Cmd, body = req.Version, req.Uid, req.Seq, req.Cmd, req.Body
+	} else {
+		rsp := &MahjongRsp{}
+		if err := proto1.Unmarshal(data, rsp); err != nil {
+			return nil, 0, err
+		}
+		env.Seq, env.Cmd, body = rsp.Seq, rsp.Cmd, rsp.Body
+	}
+
+	info, ok := cmdInfos[Cmd(env.Cmd)]
+	if !ok {
+		return nil, env.Seq, fmt.Errorf("cmd %v not registered", env.Cmd)
+	}
+	msg := reflect.New(info.msgType.Elem()).Interface()
+	var err error
+	if p.codec == CodecJSON {
+		if len(body) > 0 {
+			err = json.Unmarshal(body, msg)
+		}
+	} else {
+		err = proto1.Unmarshal(body, msg.(proto1.Message))
+	}
+	if err != nil {
+		return nil, env.Seq, err
+	}
+
+	// 登录的时候协商版本, 交给登录模块回复错误; 其他消息版本不对直接断开
+	if p.server {
+		if req, ok := msg.(*LoginReq); ok {
+			if req.Version == 0 {
+				req.Version = env.Version
+			}
+		} else if env.Version < MinVersion || env.Version > CurVersion {
+			return nil, env.Seq, fmt.Errorf("cmd %v, unsupported protocol version %v", env.Cmd, env.Version)
+		}
+	}
+	return msg, env.Seq, nil
+}
+
+func (p *EnvelopeProcessor) Marshal(msg interface{}, seq uint32) ([][]byte, error) {
+	cmd, ok := GetCmd(msg)
+	if !ok {
+		return nil, fmt.Errorf("message %v not registered", reflect.TypeOf(msg))
+	}
+	info := cmdInfos[cmd]
+	env := jsonEnvelope{Seq: seq, Module: info.module, Cmd: uint32(cmd)}
+	if p.server {
+		// 带错误码的回复, 错误码也抄一份到信封上
+		if m, ok := msg.(interface {
+			GetErrCode() int32
+			GetErrMsg() string
+		}); ok {
+			env.ErrCode, env.ErrMsg = m.GetErrCode(), m.GetErrMsg()
+		}
+	} else {
+		env.Version, env.Uid = p.Version, p.Uid
+	}
+
+	if p.codec == CodecJSON {
+		body, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		env.Body = body
+		data, err := json.Marshal(&env)
+		return [][]byte{data}, err
+	}
+
+	body, err := proto1.Marshal(msg.(proto1.Message))
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if p.server {
+		data, err = proto1.Marshal(&MahjongRsp{ErrCode: env.ErrCode, ErrMsg: env.ErrMsg, Seq: env.Seq, Module: env.Module, Cmd: env.Cmd, Body: body})
+	} else {
+		data, err = proto1.Marshal(&MahjongReq{Version: env.Version, Uid: env.Uid, Seq: env.Seq, Module: env.Module, Cmd: env.Cmd, Body: body})
+	}
+	return [][]byte{data}, err
+}
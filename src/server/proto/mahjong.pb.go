@@ -33,6 +33,8 @@ It has these top-level messages:
 	GangRsp
 	DropReq
 	DropRsp
+	DingQueReq
+	DingQueRsp
 	Seat
 	UserJoinTableMsg
 	Wave
@@ -40,6 +42,9 @@ It has these top-level messages:
 	TableOperatReq
 	TableOperatRsp
 	TableOperatMsg
+	WallMsg
+	DiceMsg
+	FlowerMsg
 	PreWinCard
 	PosMsg
 	Player
@@ -47,6 +52,12 @@ It has these top-level messages:
 	RecvorRsp
 	GetAreaReq
 	GetAreaRsp
+	HeartbeatReq
+	HeartbeatRsp
+	PlayerStatusMsg
+	TrusteeReq
+	TrusteeRsp
+	AreaInfo
 */
 package proto
 
@@ -68,35 +79,38 @@ const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 type OperatType int32
 
 const (
-	OperatType_Unkonw     OperatType = 0
-	OperatType_DealOperat OperatType = 1
-	OperatType_DrawOperat OperatType = 2
-	OperatType_HuOperat   OperatType = 4
-	OperatType_EatOperat  OperatType = 8
-	OperatType_PongOperat OperatType = 16
-	OperatType_GangOperat OperatType = 32
-	OperatType_DropOperat OperatType = 64
+	OperatType_Unkonw        OperatType = 0
+	OperatType_DealOperat    OperatType = 1
+	OperatType_DrawOperat    OperatType = 2
+	OperatType_HuOperat      OperatType = 4
+	OperatType_EatOperat     OperatType = 8
+	OperatType_PongOperat    OperatType = 16
+	OperatType_GangOperat    OperatType = 32
+	OperatType_DropOperat    OperatType = 64
+	OperatType_DingQueOperat OperatType = 128
 )
 
 var OperatType_name = map[int32]string{
-	0:  "Unkonw",
-	1:  "DealOperat",
-	2:  "DrawOperat",
-	4:  "HuOperat",
-	8:  "EatOperat",
-	16: "PongOperat",
-	32: "GangOperat",
-	64: "DropOperat",
+	0:   "Unkonw",
+	1:   "DealOperat",
+	2:   "DrawOperat",
+	4:   "HuOperat",
+	8:   "EatOperat",
+	16:  "PongOperat",
+	32:  "GangOperat",
+	64:  "DropOperat",
+	128: "DingQueOperat",
 }
 var OperatType_value = map[string]int32{
-	"Unkonw":     0,
-	"DealOperat": 1,
-	"DrawOperat": 2,
-	"HuOperat":   4,
-	"EatOperat":  8,
-	"PongOperat": 16,
-	"GangOperat": 32,
-	"DropOperat": 64,
+	"Unkonw":        0,
+	"DealOperat":    1,
+	"DrawOperat":    2,
+	"HuOperat":      4,
+	"EatOperat":     8,
+	"PongOperat":    16,
+	"GangOperat":    32,
+	"DropOperat":    64,
+	"DingQueOperat": 128,
 }
 
 func (x OperatType) String() string {
@@ -182,6 +196,33 @@ func (x TableOperat) String() string {
 }
 func (TableOperat) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
+type PlayerStatus int32
+
+const (
+	PlayerStatus_StatusOnline    PlayerStatus = 0
+	PlayerStatus_StatusOffline   PlayerStatus = 1
+	PlayerStatus_StatusTrustee   PlayerStatus = 2
+	PlayerStatus_StatusReconnect PlayerStatus = 3
+)
+
+var PlayerStatus_name = map[int32]string{
+	0: "StatusOnline",
+	1: "StatusOffline",
+	2: "StatusTrustee",
+	3: "StatusReconnect",
+}
+var PlayerStatus_value = map[string]int32{
+	"StatusOnline":    0,
+	"StatusOffline":   1,
+	"StatusTrustee":   2,
+	"StatusReconnect": 3,
+}
+
+func (x PlayerStatus) String() string {
+	return proto1.EnumName(PlayerStatus_name, int32(x))
+}
+func (PlayerStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
 type CreateTableReq_TableType int32
 
 const (
@@ -203,6 +244,38 @@ func (x CreateTableReq_TableType) String() string {
 }
 func (CreateTableReq_TableType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
 
+type CreateTableReq_RobotLevel int32
+
+const (
+	CreateTableReq_RobotDefault   CreateTableReq_RobotLevel = 0
+	CreateTableReq_RobotRandom    CreateTableReq_RobotLevel = 1
+	CreateTableReq_RobotBaseline  CreateTableReq_RobotLevel = 2
+	CreateTableReq_RobotGreedy    CreateTableReq_RobotLevel = 3
+	CreateTableReq_RobotDefensive CreateTableReq_RobotLevel = 4
+)
+
+var CreateTableReq_RobotLevel_name = map[int32]string{
+	0: "RobotDefault",
+	1: "RobotRandom",
+	2: "RobotBaseline",
+	3: "RobotGreedy",
+	4: "RobotDefensive",
+}
+var CreateTableReq_RobotLevel_value = map[string]int32{
+	"RobotDefault":   0,
+	"RobotRandom":    1,
+	"RobotBaseline":  2,
+	"RobotGreedy":    3,
+	"RobotDefensive": 4,
+}
+
+func (x CreateTableReq_RobotLevel) String() string {
+	return proto1.EnumName(CreateTableReq_RobotLevel_name, int32(x))
+}
+func (CreateTableReq_RobotLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 1}
+}
+
 type Wave_WaveType int32
 
 const (
@@ -225,12 +298,13 @@ var Wave_WaveType_value = map[string]int32{
 func (x Wave_WaveType) String() string {
 	return proto1.EnumName(Wave_WaveType_name, int32(x))
 }
-func (Wave_WaveType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
+func (Wave_WaveType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{28, 0} }
 
 type LoginReq struct {
-	Uid    uint64 `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
-	Passwd string `protobuf:"bytes,2,opt,name=passwd" json:"passwd,omitempty"`
-	Name   string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Uid     uint64 `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Passwd  string `protobuf:"bytes,2,opt,name=passwd" json:"passwd,omitempty"`
+	Name    string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Version int32  `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *LoginReq) Reset()                    { *m = LoginReq{} }
@@ -259,10 +333,20 @@ func (m *LoginReq) GetName() string {
 	return ""
 }
 
+func (m *LoginReq) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type LoginRsp struct {
 	ErrCode     int32  `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
 	ErrMsg      string `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
 	NeedRecover bool   `protobuf:"varint,3,opt,name=need_recover,json=needRecover" json:"need_recover,omitempty"`
+	Version     int32  `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
+	MinVersion  int32  `protobuf:"varint,5,opt,name=min_version,json=minVersion" json:"min_version,omitempty"`
+	MaxVersion  int32  `protobuf:"varint,6,opt,name=max_version,json=maxVersion" json:"max_version,omitempty"`
 }
 
 func (m *LoginRsp) Reset()                    { *m = LoginRsp{} }
@@ -291,9 +375,33 @@ func (m *LoginRsp) GetNeedRecover() bool {
 	return false
 }
 
+func (m *LoginRsp) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *LoginRsp) GetMinVersion() int32 {
+	if m != nil {
+		return m.MinVersion
+	}
+	return 0
+}
+
+func (m *LoginRsp) GetMaxVersion() int32 {
+	if m != nil {
+		return m.MaxVersion
+	}
+	return 0
+}
+
 type CreateTableReq struct {
-	Type int32 `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
-	Area int32 `protobuf:"varint,2,opt,name=area" json:"area,omitempty"`
+	Type        int32                       `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+	Area        int32                       `protobuf:"varint,2,opt,name=area" json:"area,omitempty"`
+	PlayerNum   int32                       `protobuf:"varint,3,opt,name=player_num,json=playerNum" json:"player_num,omitempty"`
+	RobotLevels []CreateTableReq_RobotLevel `protobuf:"varint,4,rep,packed,name=robot_levels,json=robotLevels,enum=proto.CreateTableReq_RobotLevel" json:"robot_levels,omitempty"`
+	AreaSet     bool                        `protobuf:"varint,5,opt,name=area_set,json=areaSet" json:"area_set,omitempty"`
 }
 
 func (m *CreateTableReq) Reset()                    { *m = CreateTableReq{} }
@@ -315,6 +423,27 @@ func (m *CreateTableReq) GetArea() int32 {
 	return 0
 }
 
+func (m *CreateTableReq) GetPlayerNum() int32 {
+	if m != nil {
+		return m.PlayerNum
+	}
+	return 0
+}
+
+func (m *CreateTableReq) GetRobotLevels() []CreateTableReq_RobotLevel {
+	if m != nil {
+		return m.RobotLevels
+	}
+	return nil
+}
+
+func (m *CreateTableReq) GetAreaSet() bool {
+	if m != nil {
+		return m.AreaSet
+	}
+	return false
+}
+
 type CreateTableRsp struct {
 	ErrCode int32  `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
 	ErrMsg  string `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
@@ -396,14 +525,15 @@ func (m *JoinTableRsp) GetPos() int32 {
 }
 
 type OperatReq struct {
-	Type    OperatType `protobuf:"varint,1,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
-	DealReq *DealReq   `protobuf:"bytes,2,opt,name=dealReq" json:"dealReq,omitempty"`
-	DrawReq *DrawReq   `protobuf:"bytes,3,opt,name=drawReq" json:"drawReq,omitempty"`
-	HuReq   *HuReq     `protobuf:"bytes,4,opt,name=huReq" json:"huReq,omitempty"`
-	EatReq  *EatReq    `protobuf:"bytes,5,opt,name=eatReq" json:"eatReq,omitempty"`
-	PongReq *PongReq   `protobuf:"bytes,6,opt,name=pongReq" json:"pongReq,omitempty"`
-	GangReq *GangReq   `protobuf:"bytes,7,opt,name=gangReq" json:"gangReq,omitempty"`
-	DropReq *DropReq   `protobuf:"bytes,8,opt,name=dropReq" json:"dropReq,omitempty"`
+	Type       OperatType  `protobuf:"varint,1,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
+	DealReq    *DealReq    `protobuf:"bytes,2,opt,name=dealReq" json:"dealReq,omitempty"`
+	DrawReq    *DrawReq    `protobuf:"bytes,3,opt,name=drawReq" json:"drawReq,omitempty"`
+	HuReq      *HuReq      `protobuf:"bytes,4,opt,name=huReq" json:"huReq,omitempty"`
+	EatReq     *EatReq     `protobuf:"bytes,5,opt,name=eatReq" json:"eatReq,omitempty"`
+	PongReq    *PongReq    `protobuf:"bytes,6,opt,name=pongReq" json:"pongReq,omitempty"`
+	GangReq    *GangReq    `protobuf:"bytes,7,opt,name=gangReq" json:"gangReq,omitempty"`
+	DropReq    *DropReq    `protobuf:"bytes,8,opt,name=dropReq" json:"dropReq,omitempty"`
+	DingQueReq *DingQueReq `protobuf:"bytes,9,opt,name=dingQueReq" json:"dingQueReq,omitempty"`
 }
 
 func (m *OperatReq) Reset()                    { *m = OperatReq{} }
@@ -467,17 +597,25 @@ func (m *OperatReq) GetDropReq() *DropReq {
 	return nil
 }
 
+func (m *OperatReq) GetDingQueReq() *DingQueReq {
+	if m != nil {
+		return m.DingQueReq
+	}
+	return nil
+}
+
 type OperatRsp struct {
-	ErrCode int32      `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
-	ErrMsg  string     `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
-	Type    OperatType `protobuf:"varint,3,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
-	DealRsp *DealRsp   `protobuf:"bytes,4,opt,name=dealRsp" json:"dealRsp,omitempty"`
-	DrawRsp *DrawRsp   `protobuf:"bytes,5,opt,name=drawRsp" json:"drawRsp,omitempty"`
-	HuRsp   *HuRsp     `protobuf:"bytes,6,opt,name=huRsp" json:"huRsp,omitempty"`
-	EatRsp  *EatRsp    `protobuf:"bytes,7,opt,name=eatRsp" json:"eatRsp,omitempty"`
-	PongRsp *PongRsp   `protobuf:"bytes,8,opt,name=pongRsp" json:"pongRsp,omitempty"`
-	GangRsp *GangRsp   `protobuf:"bytes,9,opt,name=gangRsp" json:"gangRsp,omitempty"`
-	DropRsp *DropRsp   `protobuf:"bytes,10,opt,name=dropRsp" json:"dropRsp,omitempty"`
+	ErrCode    int32       `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
+	ErrMsg     string      `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
+	Type       OperatType  `protobuf:"varint,3,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
+	DealRsp    *DealRsp    `protobuf:"bytes,4,opt,name=dealRsp" json:"dealRsp,omitempty"`
+	DrawRsp    *DrawRsp    `protobuf:"bytes,5,opt,name=drawRsp" json:"drawRsp,omitempty"`
+	HuRsp      *HuRsp      `protobuf:"bytes,6,opt,name=huRsp" json:"huRsp,omitempty"`
+	EatRsp     *EatRsp     `protobuf:"bytes,7,opt,name=eatRsp" json:"eatRsp,omitempty"`
+	PongRsp    *PongRsp    `protobuf:"bytes,8,opt,name=pongRsp" json:"pongRsp,omitempty"`
+	GangRsp    *GangRsp    `protobuf:"bytes,9,opt,name=gangRsp" json:"gangRsp,omitempty"`
+	DropRsp    *DropRsp    `protobuf:"bytes,10,opt,name=dropRsp" json:"dropRsp,omitempty"`
+	DingQueRsp *DingQueRsp `protobuf:"bytes,11,opt,name=dingQueRsp" json:"dingQueRsp,omitempty"`
 }
 
 func (m *OperatRsp) Reset()                    { *m = OperatRsp{} }
@@ -555,11 +693,23 @@ func (m *OperatRsp) GetDropRsp() *DropRsp {
 	return nil
 }
 
+func (m *OperatRsp) GetDingQueRsp() *DingQueRsp {
+	if m != nil {
+		return m.DingQueRsp
+	}
+	return nil
+}
+
 type DealReq struct {
-	Uid     uint64  `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
-	Cards   []int32 `protobuf:"varint,2,rep,packed,name=cards" json:"cards,omitempty"`
-	FanCard int32   `protobuf:"varint,3,opt,name=fan_card,json=fanCard" json:"fan_card,omitempty"`
-	HunCard int32   `protobuf:"varint,4,opt,name=hun_card,json=hunCard" json:"hun_card,omitempty"`
+	Uid        uint64  `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Cards      []int32 `protobuf:"varint,2,rep,packed,name=cards" json:"cards,omitempty"`
+	FanCard    int32   `protobuf:"varint,3,opt,name=fan_card,json=fanCard" json:"fan_card,omitempty"`
+	HunCard    int32   `protobuf:"varint,4,opt,name=hun_card,json=hunCard" json:"hun_card,omitempty"`
+	Flowers    []int32 `protobuf:"varint,5,rep,packed,name=flowers" json:"flowers,omitempty"`
+	Dealer     uint64  `protobuf:"varint,6,opt,name=dealer" json:"dealer,omitempty"`
+	SeatWind   int32   `protobuf:"varint,7,opt,name=seat_wind,json=seatWind" json:"seat_wind,omitempty"`
+	RoundWind  int32   `protobuf:"varint,8,opt,name=round_wind,json=roundWind" json:"round_wind,omitempty"`
+	LianZhuang int32   `protobuf:"varint,9,opt,name=lian_zhuang,json=lianZhuang" json:"lian_zhuang,omitempty"`
 }
 
 func (m *DealReq) Reset()                    { *m = DealReq{} }
@@ -595,6 +745,41 @@ func (m *DealReq) GetHunCard() int32 {
 	return 0
 }
 
+func (m *DealReq) GetFlowers() []int32 {
+	if m != nil {
+		return m.Flowers
+	}
+	return nil
+}
+
+func (m *DealReq) GetDealer() uint64 {
+	if m != nil {
+		return m.Dealer
+	}
+	return 0
+}
+
+func (m *DealReq) GetSeatWind() int32 {
+	if m != nil {
+		return m.SeatWind
+	}
+	return 0
+}
+
+func (m *DealReq) GetRoundWind() int32 {
+	if m != nil {
+		return m.RoundWind
+	}
+	return 0
+}
+
+func (m *DealReq) GetLianZhuang() int32 {
+	if m != nil {
+		return m.LianZhuang
+	}
+	return 0
+}
+
 type DealRsp struct {
 }
 
@@ -604,7 +789,8 @@ func (*DealRsp) ProtoMessage()               {}
 func (*DealRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 type DrawReq struct {
-	Card int32 `protobuf:"varint,1,opt,name=card" json:"card,omitempty"`
+	Card    int32   `protobuf:"varint,1,opt,name=card" json:"card,omitempty"`
+	Flowers []int32 `protobuf:"varint,2,rep,packed,name=flowers" json:"flowers,omitempty"`
 }
 
 func (m *DrawReq) Reset()                    { *m = DrawReq{} }
@@ -619,6 +805,13 @@ func (m *DrawReq) GetCard() int32 {
 	return 0
 }
 
+func (m *DrawReq) GetFlowers() []int32 {
+	if m != nil {
+		return m.Flowers
+	}
+	return nil
+}
+
 type DrawRsp struct {
 }
 
@@ -899,16 +1092,49 @@ func (m *DropRsp) GetDisCard() int32 {
 	return 0
 }
 
+type DingQueReq struct {
+	Suit int32 `protobuf:"varint,1,opt,name=suit" json:"suit,omitempty"`
+}
+
+func (m *DingQueReq) Reset()                    { *m = DingQueReq{} }
+func (m *DingQueReq) String() string            { return proto1.CompactTextString(m) }
+func (*DingQueReq) ProtoMessage()               {}
+func (*DingQueReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *DingQueReq) GetSuit() int32 {
+	if m != nil {
+		return m.Suit
+	}
+	return 0
+}
+
+type DingQueRsp struct {
+	Suit int32 `protobuf:"varint,1,opt,name=suit" json:"suit,omitempty"`
+}
+
+func (m *DingQueRsp) Reset()                    { *m = DingQueRsp{} }
+func (m *DingQueRsp) String() string            { return proto1.CompactTextString(m) }
+func (*DingQueRsp) ProtoMessage()               {}
+func (*DingQueRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *DingQueRsp) GetSuit() int32 {
+	if m != nil {
+		return m.Suit
+	}
+	return 0
+}
+
 type Seat struct {
 	Uid  uint64 `protobuf:"varint,2,opt,name=uid" json:"uid,omitempty"`
 	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
 	Pos  int32  `protobuf:"varint,4,opt,name=pos" json:"pos,omitempty"`
+	Wind int32  `protobuf:"varint,5,opt,name=wind" json:"wind,omitempty"`
 }
 
 func (m *Seat) Reset()                    { *m = Seat{} }
 func (m *Seat) String() string            { return proto1.CompactTextString(m) }
 func (*Seat) ProtoMessage()               {}
-func (*Seat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*Seat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *Seat) GetUid() uint64 {
 	if m != nil {
@@ -931,15 +1157,24 @@ func (m *Seat) GetPos() int32 {
 	return 0
 }
 
+func (m *Seat) GetWind() int32 {
+	if m != nil {
+		return m.Wind
+	}
+	return 0
+}
+
 type UserJoinTableMsg struct {
-	Tid   uint32  `protobuf:"varint,1,opt,name=tid" json:"tid,omitempty"`
-	Seats []*Seat `protobuf:"bytes,2,rep,name=seats" json:"seats,omitempty"`
+	Tid       uint32  `protobuf:"varint,1,opt,name=tid" json:"tid,omitempty"`
+	Seats     []*Seat `protobuf:"bytes,2,rep,name=seats" json:"seats,omitempty"`
+	Dealer    uint64  `protobuf:"varint,3,opt,name=dealer" json:"dealer,omitempty"`
+	RoundWind int32   `protobuf:"varint,4,opt,name=round_wind,json=roundWind" json:"round_wind,omitempty"`
 }
 
 func (m *UserJoinTableMsg) Reset()                    { *m = UserJoinTableMsg{} }
 func (m *UserJoinTableMsg) String() string            { return proto1.CompactTextString(m) }
 func (*UserJoinTableMsg) ProtoMessage()               {}
-func (*UserJoinTableMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*UserJoinTableMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *UserJoinTableMsg) GetTid() uint32 {
 	if m != nil {
@@ -955,6 +1190,20 @@ func (m *UserJoinTableMsg) GetSeats() []*Seat {
 	return nil
 }
 
+func (m *UserJoinTableMsg) GetDealer() uint64 {
+	if m != nil {
+		return m.Dealer
+	}
+	return 0
+}
+
+func (m *UserJoinTableMsg) GetRoundWind() int32 {
+	if m != nil {
+		return m.RoundWind
+	}
+	return 0
+}
+
 type Wave struct {
 	Cards    []int32       `protobuf:"varint,1,rep,packed,name=cards" json:"cards,omitempty"`
 	WaveType Wave_WaveType `protobuf:"varint,2,opt,name=wave_type,json=waveType,enum=proto.Wave_WaveType" json:"wave_type,omitempty"`
@@ -964,7 +1213,7 @@ type Wave struct {
 func (m *Wave) Reset()                    { *m = Wave{} }
 func (m *Wave) String() string            { return proto1.CompactTextString(m) }
 func (*Wave) ProtoMessage()               {}
-func (*Wave) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*Wave) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *Wave) GetCards() []int32 {
 	if m != nil {
@@ -988,21 +1237,22 @@ func (m *Wave) GetGangType() GangType {
 }
 
 type OperatMsg struct {
-	Uid  uint64     `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
-	Type OperatType `protobuf:"varint,2,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
-	Deal *DealRsp   `protobuf:"bytes,3,opt,name=deal" json:"deal,omitempty"`
-	Draw *DrawRsp   `protobuf:"bytes,4,opt,name=draw" json:"draw,omitempty"`
-	Hu   *HuRsp     `protobuf:"bytes,5,opt,name=hu" json:"hu,omitempty"`
-	Eat  *EatRsp    `protobuf:"bytes,6,opt,name=eat" json:"eat,omitempty"`
-	Pong *PongRsp   `protobuf:"bytes,7,opt,name=pong" json:"pong,omitempty"`
-	Gang *GangRsp   `protobuf:"bytes,8,opt,name=gang" json:"gang,omitempty"`
-	Drop *DropRsp   `protobuf:"bytes,9,opt,name=drop" json:"drop,omitempty"`
+	Uid     uint64      `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Type    OperatType  `protobuf:"varint,2,opt,name=type,enum=proto.OperatType" json:"type,omitempty"`
+	Deal    *DealRsp    `protobuf:"bytes,3,opt,name=deal" json:"deal,omitempty"`
+	Draw    *DrawRsp    `protobuf:"bytes,4,opt,name=draw" json:"draw,omitempty"`
+	Hu      *HuRsp      `protobuf:"bytes,5,opt,name=hu" json:"hu,omitempty"`
+	Eat     *EatRsp     `protobuf:"bytes,6,opt,name=eat" json:"eat,omitempty"`
+	Pong    *PongRsp    `protobuf:"bytes,7,opt,name=pong" json:"pong,omitempty"`
+	Gang    *GangRsp    `protobuf:"bytes,8,opt,name=gang" json:"gang,omitempty"`
+	Drop    *DropRsp    `protobuf:"bytes,9,opt,name=drop" json:"drop,omitempty"`
+	DingQue *DingQueRsp `protobuf:"bytes,10,opt,name=ding_que,json=dingQue" json:"ding_que,omitempty"`
 }
 
 func (m *OperatMsg) Reset()                    { *m = OperatMsg{} }
 func (m *OperatMsg) String() string            { return proto1.CompactTextString(m) }
 func (*OperatMsg) ProtoMessage()               {}
-func (*OperatMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*OperatMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *OperatMsg) GetUid() uint64 {
 	if m != nil {
@@ -1067,6 +1317,13 @@ func (m *OperatMsg) GetDrop() *DropRsp {
 	return nil
 }
 
+func (m *OperatMsg) GetDingQue() *DingQueRsp {
+	if m != nil {
+		return m.DingQue
+	}
+	return nil
+}
+
 type TableOperatReq struct {
 	Type TableOperat `protobuf:"varint,1,opt,name=type,enum=proto.TableOperat" json:"type,omitempty"`
 }
@@ -1074,7 +1331,7 @@ type TableOperatReq struct {
 func (m *TableOperatReq) Reset()                    { *m = TableOperatReq{} }
 func (m *TableOperatReq) String() string            { return proto1.CompactTextString(m) }
 func (*TableOperatReq) ProtoMessage()               {}
-func (*TableOperatReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*TableOperatReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *TableOperatReq) GetType() TableOperat {
 	if m != nil {
@@ -1091,7 +1348,7 @@ type TableOperatRsp struct {
 func (m *TableOperatRsp) Reset()                    { *m = TableOperatRsp{} }
 func (m *TableOperatRsp) String() string            { return proto1.CompactTextString(m) }
 func (*TableOperatRsp) ProtoMessage()               {}
-func (*TableOperatRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*TableOperatRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *TableOperatRsp) GetType() TableOperat {
 	if m != nil {
@@ -1116,7 +1373,7 @@ type TableOperatMsg struct {
 func (m *TableOperatMsg) Reset()                    { *m = TableOperatMsg{} }
 func (m *TableOperatMsg) String() string            { return proto1.CompactTextString(m) }
 func (*TableOperatMsg) ProtoMessage()               {}
-func (*TableOperatMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*TableOperatMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *TableOperatMsg) GetUid() uint64 {
 	if m != nil {
@@ -1139,6 +1396,110 @@ func (m *TableOperatMsg) GetOK() bool {
 	return false
 }
 
+type WallMsg struct {
+	Dice      []int32 `protobuf:"varint,1,rep,packed,name=dice" json:"dice,omitempty"`
+	BreakWall int32   `protobuf:"varint,2,opt,name=break_wall,json=breakWall" json:"break_wall,omitempty"`
+	BreakPos  int32   `protobuf:"varint,3,opt,name=break_pos,json=breakPos" json:"break_pos,omitempty"`
+	Total     int32   `protobuf:"varint,4,opt,name=total" json:"total,omitempty"`
+	LiveDrawn int32   `protobuf:"varint,5,opt,name=live_drawn,json=liveDrawn" json:"live_drawn,omitempty"`
+	DeadDrawn int32   `protobuf:"varint,6,opt,name=dead_drawn,json=deadDrawn" json:"dead_drawn,omitempty"`
+}
+
+func (m *WallMsg) Reset()                    { *m = WallMsg{} }
+func (m *WallMsg) String() string            { return proto1.CompactTextString(m) }
+func (*WallMsg) ProtoMessage()               {}
+func (*WallMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *WallMsg) GetDice() []int32 {
+	if m != nil {
+		return m.Dice
+	}
+	return nil
+}
+
+func (m *WallMsg) GetBreakWall() int32 {
+	if m != nil {
+		return m.BreakWall
+	}
+	return 0
+}
+
+func (m *WallMsg) GetBreakPos() int32 {
+	if m != nil {
+		return m.BreakPos
+	}
+	return 0
+}
+
+func (m *WallMsg) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *WallMsg) GetLiveDrawn() int32 {
+	if m != nil {
+		return m.LiveDrawn
+	}
+	return 0
+}
+
+func (m *WallMsg) GetDeadDrawn() int32 {
+	if m != nil {
+		return m.DeadDrawn
+	}
+	return 0
+}
+
+type DiceMsg struct {
+	Dealer uint64   `protobuf:"varint,1,opt,name=dealer" json:"dealer,omitempty"`
+	Wall   *WallMsg `protobuf:"bytes,2,opt,name=wall" json:"wall,omitempty"`
+}
+
+func (m *DiceMsg) Reset()                    { *m = DiceMsg{} }
+func (m *DiceMsg) String() string            { return proto1.CompactTextString(m) }
+func (*DiceMsg) ProtoMessage()               {}
+func (*DiceMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *DiceMsg) GetDealer() uint64 {
+	if m != nil {
+		return m.Dealer
+	}
+	return 0
+}
+
+func (m *DiceMsg) GetWall() *WallMsg {
+	if m != nil {
+		return m.Wall
+	}
+	return nil
+}
+
+type FlowerMsg struct {
+	Uid     uint64  `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Flowers []int32 `protobuf:"varint,2,rep,packed,name=flowers" json:"flowers,omitempty"`
+}
+
+func (m *FlowerMsg) Reset()                    { *m = FlowerMsg{} }
+func (m *FlowerMsg) String() string            { return proto1.CompactTextString(m) }
+func (*FlowerMsg) ProtoMessage()               {}
+func (*FlowerMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *FlowerMsg) GetUid() uint64 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *FlowerMsg) GetFlowers() []int32 {
+	if m != nil {
+		return m.Flowers
+	}
+	return nil
+}
+
 type PreWinCard struct {
 	Card int32  `protobuf:"varint,1,opt,name=card" json:"card,omitempty"`
 	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -1147,7 +1508,7 @@ type PreWinCard struct {
 func (m *PreWinCard) Reset()                    { *m = PreWinCard{} }
 func (m *PreWinCard) String() string            { return proto1.CompactTextString(m) }
 func (*PreWinCard) ProtoMessage()               {}
-func (*PreWinCard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*PreWinCard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *PreWinCard) GetCard() int32 {
 	if m != nil {
@@ -1171,7 +1532,7 @@ type PosMsg struct {
 func (m *PosMsg) Reset()                    { *m = PosMsg{} }
 func (m *PosMsg) String() string            { return proto1.CompactTextString(m) }
 func (*PosMsg) ProtoMessage()               {}
-func (*PosMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*PosMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *PosMsg) GetUid() uint64 {
 	if m != nil {
@@ -1199,12 +1560,19 @@ type Player struct {
 	Pos            []*PosMsg             `protobuf:"bytes,9,rep,name=pos" json:"pos,omitempty"`
 	HunCard        int32                 `protobuf:"varint,10,opt,name=hun_card,json=hunCard" json:"hun_card,omitempty"`
 	CancelHu       bool                  `protobuf:"varint,11,opt,name=cancel_hu,json=cancelHu" json:"cancel_hu,omitempty"`
+	Que            int32                 `protobuf:"varint,12,opt,name=que" json:"que,omitempty"`
+	Flowers        []int32               `protobuf:"varint,13,rep,packed,name=flowers" json:"flowers,omitempty"`
+	Dealer         uint64                `protobuf:"varint,14,opt,name=dealer" json:"dealer,omitempty"`
+	SeatWind       int32                 `protobuf:"varint,15,opt,name=seat_wind,json=seatWind" json:"seat_wind,omitempty"`
+	RoundWind      int32                 `protobuf:"varint,16,opt,name=round_wind,json=roundWind" json:"round_wind,omitempty"`
+	LianZhuang     int32                 `protobuf:"varint,17,opt,name=lian_zhuang,json=lianZhuang" json:"lian_zhuang,omitempty"`
+	Wall           *WallMsg              `protobuf:"bytes,18,opt,name=wall" json:"wall,omitempty"`
 }
 
 func (m *Player) Reset()                    { *m = Player{} }
 func (m *Player) String() string            { return proto1.CompactTextString(m) }
 func (*Player) ProtoMessage()               {}
-func (*Player) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*Player) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *Player) GetUid() uint64 {
 	if m != nil {
@@ -1283,13 +1651,62 @@ func (m *Player) GetCancelHu() bool {
 	return false
 }
 
+func (m *Player) GetQue() int32 {
+	if m != nil {
+		return m.Que
+	}
+	return 0
+}
+
+func (m *Player) GetFlowers() []int32 {
+	if m != nil {
+		return m.Flowers
+	}
+	return nil
+}
+
+func (m *Player) GetDealer() uint64 {
+	if m != nil {
+		return m.Dealer
+	}
+	return 0
+}
+
+func (m *Player) GetSeatWind() int32 {
+	if m != nil {
+		return m.SeatWind
+	}
+	return 0
+}
+
+func (m *Player) GetRoundWind() int32 {
+	if m != nil {
+		return m.RoundWind
+	}
+	return 0
+}
+
+func (m *Player) GetLianZhuang() int32 {
+	if m != nil {
+		return m.LianZhuang
+	}
+	return 0
+}
+
+func (m *Player) GetWall() *WallMsg {
+	if m != nil {
+		return m.Wall
+	}
+	return nil
+}
+
 type RecvorReq struct {
 }
 
 func (m *RecvorReq) Reset()                    { *m = RecvorReq{} }
 func (m *RecvorReq) String() string            { return proto1.CompactTextString(m) }
 func (*RecvorReq) ProtoMessage()               {}
-func (*RecvorReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*RecvorReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 type RecvorRsp struct {
 	ErrCode uint32    `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
@@ -1300,7 +1717,7 @@ type RecvorRsp struct {
 func (m *RecvorRsp) Reset()                    { *m = RecvorRsp{} }
 func (m *RecvorRsp) String() string            { return proto1.CompactTextString(m) }
 func (*RecvorRsp) ProtoMessage()               {}
-func (*RecvorRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*RecvorRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *RecvorRsp) GetErrCode() uint32 {
 	if m != nil {
@@ -1329,19 +1746,20 @@ type GetAreaReq struct {
 func (m *GetAreaReq) Reset()                    { *m = GetAreaReq{} }
 func (m *GetAreaReq) String() string            { return proto1.CompactTextString(m) }
 func (*GetAreaReq) ProtoMessage()               {}
-func (*GetAreaReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*GetAreaReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 type GetAreaRsp struct {
-	ErrCode  int32  `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
-	ErrMsg   string `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
-	AreaId   int32  `protobuf:"varint,3,opt,name=area_id,json=areaId" json:"area_id,omitempty"`
-	AreaName string `protobuf:"bytes,4,opt,name=area_name,json=areaName" json:"area_name,omitempty"`
+	ErrCode  int32       `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
+	ErrMsg   string      `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
+	AreaId   int32       `protobuf:"varint,3,opt,name=area_id,json=areaId" json:"area_id,omitempty"`
+	AreaName string      `protobuf:"bytes,4,opt,name=area_name,json=areaName" json:"area_name,omitempty"`
+	Areas    []*AreaInfo `protobuf:"bytes,5,rep,name=areas" json:"areas,omitempty"`
 }
 
 func (m *GetAreaRsp) Reset()                    { *m = GetAreaRsp{} }
 func (m *GetAreaRsp) String() string            { return proto1.CompactTextString(m) }
 func (*GetAreaRsp) ProtoMessage()               {}
-func (*GetAreaRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*GetAreaRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 func (m *GetAreaRsp) GetErrCode() int32 {
 	if m != nil {
@@ -1371,6 +1789,157 @@ func (m *GetAreaRsp) GetAreaName() string {
 	return ""
 }
 
+func (m *GetAreaRsp) GetAreas() []*AreaInfo {
+	if m != nil {
+		return m.Areas
+	}
+	return nil
+}
+
+type HeartbeatReq struct {
+	ClientTime int64 `protobuf:"varint,1,opt,name=client_time,json=clientTime" json:"client_time,omitempty"`
+}
+
+func (m *HeartbeatReq) Reset()                    { *m = HeartbeatReq{} }
+func (m *HeartbeatReq) String() string            { return proto1.CompactTextString(m) }
+func (*HeartbeatReq) ProtoMessage()               {}
+func (*HeartbeatReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *HeartbeatReq) GetClientTime() int64 {
+	if m != nil {
+		return m.ClientTime
+	}
+	return 0
+}
+
+type HeartbeatRsp struct {
+	ClientTime int64 `protobuf:"varint,1,opt,name=client_time,json=clientTime" json:"client_time,omitempty"`
+	ServerTime int64 `protobuf:"varint,2,opt,name=server_time,json=serverTime" json:"server_time,omitempty"`
+}
+
+func (m *HeartbeatRsp) Reset()                    { *m = HeartbeatRsp{} }
+func (m *HeartbeatRsp) String() string            { return proto1.CompactTextString(m) }
+func (*HeartbeatRsp) ProtoMessage()               {}
+func (*HeartbeatRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *HeartbeatRsp) GetClientTime() int64 {
+	if m != nil {
+		return m.ClientTime
+	}
+	return 0
+}
+
+func (m *HeartbeatRsp) GetServerTime() int64 {
+	if m != nil {
+		return m.ServerTime
+	}
+	return 0
+}
+
+type PlayerStatusMsg struct {
+	Uid    uint64       `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Status PlayerStatus `protobuf:"varint,2,opt,name=status,enum=proto.PlayerStatus" json:"status,omitempty"`
+}
+
+func (m *PlayerStatusMsg) Reset()                    { *m = PlayerStatusMsg{} }
+func (m *PlayerStatusMsg) String() string            { return proto1.CompactTextString(m) }
+func (*PlayerStatusMsg) ProtoMessage()               {}
+func (*PlayerStatusMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *PlayerStatusMsg) GetUid() uint64 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *PlayerStatusMsg) GetStatus() PlayerStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PlayerStatus_StatusOnline
+}
+
+type TrusteeReq struct {
+	On bool `protobuf:"varint,1,opt,name=on" json:"on,omitempty"`
+}
+
+func (m *TrusteeReq) Reset()                    { *m = TrusteeReq{} }
+func (m *TrusteeReq) String() string            { return proto1.CompactTextString(m) }
+func (*TrusteeReq) ProtoMessage()               {}
+func (*TrusteeReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *TrusteeReq) GetOn() bool {
+	if m != nil {
+		return m.On
+	}
+	return false
+}
+
+type TrusteeRsp struct {
+	ErrCode int32  `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
+	ErrMsg  string `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
+	On      bool   `protobuf:"varint,3,opt,name=on" json:"on,omitempty"`
+}
+
+func (m *TrusteeRsp) Reset()                    { *m = TrusteeRsp{} }
+func (m *TrusteeRsp) String() string            { return proto1.CompactTextString(m) }
+func (*TrusteeRsp) ProtoMessage()               {}
+func (*TrusteeRsp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *TrusteeRsp) GetErrCode() int32 {
+	if m != nil {
+		return m.ErrCode
+	}
+	return 0
+}
+
+func (m *TrusteeRsp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *TrusteeRsp) GetOn() bool {
+	if m != nil {
+		return m.On
+	}
+	return false
+}
+
+type AreaInfo struct {
+	AreaId     int32   `protobuf:"varint,1,opt,name=area_id,json=areaId" json:"area_id,omitempty"`
+	AreaName   string  `protobuf:"bytes,2,opt,name=area_name,json=areaName" json:"area_name,omitempty"`
+	PlayerNums []int32 `protobuf:"varint,3,rep,packed,name=player_nums,json=playerNums" json:"player_nums,omitempty"`
+}
+
+func (m *AreaInfo) Reset()                    { *m = AreaInfo{} }
+func (m *AreaInfo) String() string            { return proto1.CompactTextString(m) }
+func (*AreaInfo) ProtoMessage()               {}
+func (*AreaInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *AreaInfo) GetAreaId() int32 {
+	if m != nil {
+		return m.AreaId
+	}
+	return 0
+}
+
+func (m *AreaInfo) GetAreaName() string {
+	if m != nil {
+		return m.AreaName
+	}
+	return ""
+}
+
+func (m *AreaInfo) GetPlayerNums() []int32 {
+	if m != nil {
+		return m.PlayerNums
+	}
+	return nil
+}
+
 func init() {
 	proto1.RegisterType((*LoginReq)(nil), "proto.LoginReq")
 	proto1.RegisterType((*LoginRsp)(nil), "proto.LoginRsp")
@@ -1396,6 +1965,8 @@ func init() {
 	proto1.RegisterType((*GangRsp)(nil), "proto.GangRsp")
 	proto1.RegisterType((*DropReq)(nil), "proto.DropReq")
 	proto1.RegisterType((*DropRsp)(nil), "proto.DropRsp")
+	proto1.RegisterType((*DingQueReq)(nil), "proto.DingQueReq")
+	proto1.RegisterType((*DingQueRsp)(nil), "proto.DingQueRsp")
 	proto1.RegisterType((*Seat)(nil), "proto.Seat")
 	proto1.RegisterType((*UserJoinTableMsg)(nil), "proto.UserJoinTableMsg")
 	proto1.RegisterType((*Wave)(nil), "proto.Wave")
@@ -1403,6 +1974,9 @@ func init() {
 	proto1.RegisterType((*TableOperatReq)(nil), "proto.TableOperatReq")
 	proto1.RegisterType((*TableOperatRsp)(nil), "proto.TableOperatRsp")
 	proto1.RegisterType((*TableOperatMsg)(nil), "proto.TableOperatMsg")
+	proto1.RegisterType((*WallMsg)(nil), "proto.WallMsg")
+	proto1.RegisterType((*DiceMsg)(nil), "proto.DiceMsg")
+	proto1.RegisterType((*FlowerMsg)(nil), "proto.FlowerMsg")
 	proto1.RegisterType((*PreWinCard)(nil), "proto.PreWinCard")
 	proto1.RegisterType((*PosMsg)(nil), "proto.PosMsg")
 	proto1.RegisterType((*Player)(nil), "proto.Player")
@@ -1410,112 +1984,164 @@ func init() {
 	proto1.RegisterType((*RecvorRsp)(nil), "proto.RecvorRsp")
 	proto1.RegisterType((*GetAreaReq)(nil), "proto.GetAreaReq")
 	proto1.RegisterType((*GetAreaRsp)(nil), "proto.GetAreaRsp")
+	proto1.RegisterType((*HeartbeatReq)(nil), "proto.HeartbeatReq")
+	proto1.RegisterType((*HeartbeatRsp)(nil), "proto.HeartbeatRsp")
+	proto1.RegisterType((*PlayerStatusMsg)(nil), "proto.PlayerStatusMsg")
+	proto1.RegisterType((*TrusteeReq)(nil), "proto.TrusteeReq")
+	proto1.RegisterType((*TrusteeRsp)(nil), "proto.TrusteeRsp")
+	proto1.RegisterType((*AreaInfo)(nil), "proto.AreaInfo")
 	proto1.RegisterEnum("proto.OperatType", OperatType_name, OperatType_value)
 	proto1.RegisterEnum("proto.HuType", HuType_name, HuType_value)
 	proto1.RegisterEnum("proto.GangType", GangType_name, GangType_value)
 	proto1.RegisterEnum("proto.TableOperat", TableOperat_name, TableOperat_value)
+	proto1.RegisterEnum("proto.PlayerStatus", PlayerStatus_name, PlayerStatus_value)
 	proto1.RegisterEnum("proto.CreateTableReq_TableType", CreateTableReq_TableType_name, CreateTableReq_TableType_value)
+	proto1.RegisterEnum("proto.CreateTableReq_RobotLevel", CreateTableReq_RobotLevel_name, CreateTableReq_RobotLevel_value)
 	proto1.RegisterEnum("proto.Wave_WaveType", Wave_WaveType_name, Wave_WaveType_value)
 }
 
 func init() { proto1.RegisterFile("mahjong.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1525 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4b, 0x6f, 0xdb, 0x46,
-	0x10, 0xb6, 0x28, 0x52, 0xa2, 0x46, 0x8f, 0x28, 0x8b, 0xa0, 0x51, 0x9a, 0xa4, 0xb1, 0xd9, 0x3c,
-	0x1c, 0x37, 0x0d, 0xda, 0xa4, 0x28, 0x82, 0x1c, 0xda, 0xba, 0x8e, 0x11, 0xa5, 0x79, 0x39, 0x1b,
-	0x07, 0x01, 0x7a, 0x11, 0xd6, 0xe2, 0x46, 0x62, 0x2d, 0x93, 0x0c, 0x97, 0xb4, 0xe0, 0x73, 0xd1,
-	0x3f, 0xd4, 0x4b, 0x7f, 0x45, 0xd1, 0xbf, 0x54, 0xcc, 0xec, 0x52, 0x22, 0x65, 0x06, 0x31, 0x7c,
-	0x91, 0x76, 0xe6, 0xfb, 0x66, 0x1f, 0x33, 0xb3, 0x33, 0x4b, 0xe8, 0x1e, 0x89, 0xe9, 0x1f, 0x51,
-	0x38, 0xb9, 0x1f, 0x27, 0x51, 0x1a, 0x31, 0x87, 0xfe, 0xbc, 0x21, 0xb8, 0x2f, 0xa2, 0x49, 0x10,
-	0x72, 0xf9, 0x91, 0xf5, 0xa1, 0x9e, 0x05, 0xfe, 0xa0, 0xb6, 0x5e, 0xdb, 0xb4, 0x39, 0x0e, 0xd9,
-	0x17, 0xd0, 0x88, 0x85, 0x52, 0x73, 0x7f, 0x60, 0xad, 0xd7, 0x36, 0x5b, 0xdc, 0x48, 0x8c, 0x81,
-	0x1d, 0x8a, 0x23, 0x39, 0xa8, 0x93, 0x96, 0xc6, 0x9e, 0xc8, 0x67, 0x52, 0x31, 0xbb, 0x02, 0xae,
-	0x4c, 0x92, 0xd1, 0x38, 0xf2, 0x25, 0x4d, 0xe7, 0xf0, 0xa6, 0x4c, 0x92, 0x9d, 0xc8, 0x97, 0xec,
-	0x32, 0xe0, 0x70, 0x74, 0xa4, 0x26, 0xf9, 0x9c, 0x32, 0x49, 0x5e, 0xaa, 0x09, 0xdb, 0x80, 0x4e,
-	0x28, 0xa5, 0x3f, 0x4a, 0xe4, 0x38, 0x3a, 0x96, 0x09, 0xcd, 0xed, 0xf2, 0x36, 0xea, 0xb8, 0x56,
-	0x79, 0x12, 0x7a, 0x3b, 0x89, 0x14, 0xa9, 0xdc, 0x17, 0x07, 0x33, 0x89, 0x5b, 0x66, 0x60, 0xa7,
-	0x27, 0x71, 0xbe, 0x08, 0x8d, 0x51, 0x27, 0x12, 0x29, 0x68, 0x7a, 0x87, 0xd3, 0xd8, 0xfb, 0x06,
-	0x5a, 0x64, 0xb3, 0x8f, 0x84, 0x1e, 0x80, 0x9e, 0x20, 0x3a, 0x88, 0xd2, 0xfe, 0xda, 0x42, 0x7e,
-	0x15, 0x1d, 0x89, 0x59, 0xbf, 0xe6, 0x8d, 0xca, 0xcb, 0x9c, 0xf3, 0x3c, 0x57, 0xc0, 0x4d, 0xd1,
-	0x7e, 0x14, 0xf8, 0x74, 0x96, 0x2e, 0x6f, 0x92, 0xfc, 0xcc, 0xf7, 0xee, 0x42, 0xe7, 0xb7, 0x28,
-	0x08, 0x17, 0xa7, 0x28, 0x52, 0xad, 0x32, 0x75, 0xbf, 0x48, 0x3d, 0xe7, 0x4e, 0xfa, 0x50, 0x8f,
-	0x23, 0x45, 0x9b, 0x70, 0x38, 0x0e, 0xbd, 0xff, 0x2c, 0x68, 0xbd, 0x8e, 0x65, 0x22, 0x52, 0x5c,
-	0xfe, 0x56, 0xc1, 0x89, 0xbd, 0x07, 0x17, 0x75, 0x82, 0xdc, 0xd7, 0x38, 0x3a, 0xcc, 0xf8, 0x75,
-	0x13, 0x9a, 0xbe, 0x14, 0x33, 0x2e, 0x3f, 0xd2, 0xfc, 0xed, 0x07, 0x3d, 0xc3, 0x7c, 0xa2, 0xb5,
-	0x3c, 0x87, 0x89, 0x99, 0x88, 0x39, 0x32, 0xeb, 0x65, 0xa6, 0xd6, 0xf2, 0x1c, 0x66, 0x1e, 0x38,
-	0xd3, 0x0c, 0x79, 0x36, 0xf1, 0x3a, 0x86, 0x37, 0x44, 0x1d, 0xd7, 0x10, 0xbb, 0x05, 0x0d, 0x49,
-	0x1b, 0x1d, 0x38, 0x44, 0xea, 0x1a, 0xd2, 0x2e, 0x29, 0xb9, 0x01, 0x71, 0xd1, 0x38, 0x0a, 0x27,
-	0xc8, 0x6b, 0x94, 0x16, 0xdd, 0xd3, 0x5a, 0x9e, 0xc3, 0xc8, 0x9c, 0x08, 0xcd, 0x6c, 0x96, 0x98,
-	0x4f, 0x85, 0x61, 0x1a, 0x58, 0x1f, 0x24, 0x8a, 0x91, 0xe9, 0xae, 0x1c, 0x84, 0xb4, 0x3c, 0x87,
-	0xbd, 0x3f, 0xeb, 0x0b, 0x8f, 0x9e, 0x33, 0x4a, 0x79, 0x14, 0xea, 0x67, 0x8b, 0x82, 0x8a, 0x8d,
-	0xcf, 0x4a, 0x51, 0x50, 0x31, 0xcf, 0xe1, 0x45, 0x14, 0x54, 0x6c, 0x1c, 0x57, 0x8a, 0x02, 0x31,
-	0xf5, 0xc0, 0x44, 0x41, 0xc5, 0xc6, 0x71, 0x85, 0x28, 0xa8, 0x98, 0x6b, 0x28, 0x8f, 0x82, 0x8a,
-	0x8d, 0xcf, 0x8a, 0x51, 0x50, 0x31, 0x37, 0xe0, 0x22, 0x0a, 0x2a, 0x5e, 0xf1, 0xd8, 0x9e, 0xd6,
-	0xf2, 0x1c, 0x5e, 0x44, 0x41, 0xc5, 0x83, 0xd6, 0xe9, 0x28, 0x20, 0xd3, 0xc0, 0x8b, 0x28, 0xa8,
-	0x78, 0x00, 0xa7, 0xa3, 0xa0, 0x0f, 0x42, 0x03, 0x6f, 0x02, 0x4d, 0x93, 0x8c, 0x15, 0xc5, 0xec,
-	0x12, 0x38, 0x63, 0x91, 0xf8, 0x6a, 0x60, 0xad, 0xd7, 0x37, 0x1d, 0xae, 0x05, 0x0c, 0xd5, 0x07,
-	0x11, 0x8e, 0x50, 0x30, 0x37, 0xa4, 0xf9, 0x41, 0x84, 0x3b, 0x22, 0xf1, 0x11, 0x9a, 0x66, 0x06,
-	0xb2, 0x35, 0x34, 0xcd, 0x08, 0xf2, 0x5a, 0x66, 0x21, 0x15, 0x7b, 0xd7, 0xa1, 0x69, 0xd2, 0x1a,
-	0x2b, 0x0f, 0x91, 0x4d, 0x35, 0x1a, 0xe7, 0x4c, 0xed, 0x66, 0x8f, 0x83, 0x43, 0x89, 0x5d, 0xc5,
-	0x63, 0x1b, 0x26, 0xfc, 0x16, 0x85, 0xbf, 0xbb, 0x08, 0x41, 0x21, 0xf4, 0x0c, 0xec, 0x59, 0xa4,
-	0x74, 0x86, 0xd8, 0x9c, 0xc6, 0xde, 0x01, 0xcd, 0xa9, 0x62, 0xd6, 0x03, 0x2b, 0x3a, 0xa4, 0x19,
-	0x5d, 0x6e, 0x45, 0x87, 0x8b, 0x35, 0xac, 0x8a, 0x35, 0xea, 0x9f, 0x5f, 0xc3, 0x2e, 0xac, 0xf1,
-	0x33, 0xd4, 0x77, 0x45, 0xca, 0xae, 0x42, 0x6b, 0x2a, 0x42, 0x7f, 0x64, 0xb6, 0x8e, 0x3e, 0x74,
-	0x51, 0x41, 0xbe, 0xba, 0x0a, 0xad, 0xb9, 0x38, 0x96, 0x23, 0xb3, 0x26, 0x81, 0xa8, 0x20, 0x6f,
-	0xdd, 0x86, 0x86, 0xbe, 0xac, 0xec, 0x1a, 0xd4, 0xa5, 0x48, 0xc9, 0xba, 0xfd, 0x00, 0x0a, 0x29,
-	0x84, 0x6a, 0xef, 0x47, 0xcd, 0xab, 0x38, 0x8d, 0xb1, 0xd3, 0x75, 0xe7, 0x94, 0xdd, 0x75, 0x68,
-	0x9a, 0x4b, 0x5e, 0x19, 0x82, 0x6f, 0x0d, 0x7c, 0x36, 0x2f, 0x79, 0xdb, 0x60, 0x63, 0x0a, 0x2e,
-	0xf3, 0xa5, 0x56, 0xcc, 0x97, 0xaf, 0x4b, 0x71, 0xba, 0x50, 0xc8, 0xd9, 0xa5, 0x17, 0xbd, 0x2d,
-	0x68, 0x9a, 0x5a, 0xc2, 0x6e, 0x80, 0x8d, 0x79, 0x6c, 0x8e, 0xdc, 0x2e, 0xe6, 0x38, 0x01, 0xde,
-	0x63, 0xc3, 0xad, 0xd8, 0x5d, 0x6e, 0xab, 0x8f, 0x5d, 0x61, 0x4b, 0xb9, 0x47, 0x05, 0xa8, 0xf2,
-	0x24, 0x37, 0x0d, 0xac, 0x2b, 0x92, 0x1f, 0xa8, 0x52, 0x9a, 0xfb, 0x81, 0xa2, 0xe8, 0xfc, 0x04,
-	0xf6, 0x5b, 0x29, 0xd2, 0xfc, 0xc6, 0x58, 0xcb, 0x1b, 0x53, 0xd1, 0xe6, 0xf3, 0x66, 0x62, 0x2f,
-	0x9b, 0xc9, 0x53, 0xe8, 0xbf, 0x53, 0x32, 0x59, 0xb4, 0x29, 0xd3, 0x72, 0x52, 0x73, 0xfb, 0xba,
-	0x1c, 0x87, 0x6c, 0x03, 0x1c, 0x25, 0x45, 0xaa, 0x6f, 0xdf, 0xf2, 0x30, 0xb8, 0x32, 0xd7, 0x88,
-	0xf7, 0x77, 0x0d, 0xec, 0xf7, 0xe2, 0x58, 0x7e, 0xc2, 0xf3, 0xdf, 0x9b, 0x14, 0x2b, 0xb8, 0xff,
-	0x92, 0x99, 0x05, 0xad, 0xe8, 0x87, 0x62, 0x40, 0x89, 0x47, 0x9d, 0xfe, 0x1e, 0xb4, 0xd0, 0x4f,
-	0xa3, 0x42, 0xd6, 0x9f, 0x8a, 0x98, 0x3b, 0x31, 0x23, 0xef, 0x21, 0xb8, 0xf9, 0x1c, 0xac, 0x0d,
-	0xcd, 0x5d, 0x91, 0xa2, 0xd8, 0x5f, 0x63, 0x1d, 0x70, 0x31, 0x81, 0x48, 0xaa, 0xa1, 0x84, 0xc6,
-	0x24, 0x59, 0xde, 0x3f, 0x8b, 0x56, 0x6a, 0xce, 0xbd, 0x52, 0x75, 0x6e, 0x95, 0xf2, 0xe5, 0x93,
-	0x65, 0xdd, 0x03, 0x1b, 0xeb, 0xf6, 0x6a, 0xbf, 0x34, 0x35, 0x9d, 0x30, 0xe2, 0x24, 0x62, 0xbe,
-	0x5a, 0xf7, 0x4d, 0x35, 0x27, 0x8c, 0x5d, 0x03, 0x6b, 0x9a, 0x99, 0x7a, 0x5f, 0xae, 0xe3, 0xd6,
-	0x34, 0x63, 0x37, 0xf4, 0x35, 0x6a, 0x54, 0x55, 0x70, 0x44, 0x70, 0x09, 0xac, 0xcf, 0x2b, 0x7d,
-	0x31, 0xaf, 0xdd, 0x84, 0x21, 0x87, 0xb2, 0xd2, 0xad, 0xac, 0xda, 0x84, 0xe9, 0xad, 0x46, 0xab,
-	0x95, 0x3d, 0xaf, 0xd7, 0x84, 0x79, 0x8f, 0xa0, 0x47, 0xf9, 0xb2, 0x7c, 0x88, 0xdc, 0x2e, 0x3d,
-	0x44, 0x98, 0xb1, 0x2a, 0x92, 0xf4, 0xf5, 0x1a, 0x96, 0x2d, 0x55, 0x8c, 0x96, 0xfb, 0x9f, 0xb1,
-	0x34, 0x4f, 0x3f, 0xbc, 0x61, 0x56, 0x7e, 0xc3, 0xbc, 0xdf, 0x4b, 0x33, 0x55, 0x47, 0xf0, 0x76,
-	0x29, 0x82, 0x9f, 0xdc, 0x15, 0xce, 0xfd, 0xfa, 0xb9, 0x79, 0xb6, 0x5a, 0xaf, 0x9f, 0x7b, 0x3f,
-	0x00, 0xec, 0x25, 0xf2, 0x7d, 0xa0, 0x9b, 0x49, 0x55, 0xcd, 0xcf, 0xef, 0x97, 0x55, 0x78, 0x46,
-	0xdf, 0x83, 0xc6, 0x5e, 0xa4, 0xaa, 0x77, 0x62, 0xee, 0x9e, 0xb5, 0xbc, 0x7b, 0xff, 0xd6, 0xa1,
-	0xb1, 0x37, 0x13, 0x27, 0x32, 0x39, 0x73, 0xc3, 0xdb, 0x00, 0x07, 0xef, 0x07, 0xbe, 0x07, 0x8b,
-	0x17, 0x11, 0xd3, 0x99, 0x6b, 0x84, 0x5d, 0x07, 0xc0, 0x08, 0x8d, 0xb4, 0xb5, 0x4d, 0xd6, 0x2d,
-	0xd4, 0xec, 0xe4, 0x2d, 0x93, 0x5e, 0xea, 0xd3, 0x2c, 0x1c, 0x38, 0x04, 0x36, 0x51, 0x1e, 0x66,
-	0x21, 0xbb, 0x0b, 0x17, 0x73, 0x68, 0x34, 0x0f, 0xd2, 0xe9, 0x48, 0x9e, 0xc8, 0x41, 0x83, 0x38,
-	0x3d, 0xc3, 0x79, 0x1f, 0xa4, 0xd3, 0xdd, 0x13, 0xc9, 0x6e, 0x42, 0x2f, 0x50, 0x23, 0x62, 0x67,
-	0xb1, 0x2f, 0x52, 0x39, 0x68, 0xae, 0xd7, 0x37, 0x5d, 0xde, 0x09, 0xd4, 0x2b, 0x29, 0xfd, 0x77,
-	0xa4, 0x63, 0xdb, 0xd0, 0x89, 0x13, 0x39, 0x0f, 0x42, 0xb3, 0x19, 0x97, 0x36, 0xfd, 0x55, 0x9e,
-	0x98, 0x74, 0xf4, 0xfb, 0x7b, 0xc4, 0xa0, 0xcd, 0xed, 0x86, 0x69, 0x72, 0xc2, 0xdb, 0xf1, 0x52,
-	0x83, 0x49, 0x8f, 0x5e, 0x6b, 0x91, 0x65, 0x77, 0x91, 0xd2, 0xe8, 0x63, 0x72, 0x62, 0xa9, 0xcf,
-	0x43, 0xa9, 0xcf, 0x63, 0x5b, 0x1b, 0x8b, 0x70, 0x2c, 0x67, 0xa3, 0x69, 0x36, 0x68, 0x53, 0x68,
-	0x5d, 0xad, 0x18, 0x66, 0x5f, 0xbe, 0x81, 0xfe, 0xea, 0xca, 0x18, 0x85, 0x43, 0x79, 0x62, 0xa2,
-	0x8c, 0x43, 0x76, 0x07, 0x9c, 0x63, 0x31, 0xcb, 0xa4, 0xa9, 0xe2, 0x79, 0x05, 0x58, 0xa6, 0x06,
-	0xd7, 0xf8, 0x63, 0xeb, 0x51, 0xcd, 0x6b, 0x43, 0x8b, 0xcb, 0xf1, 0x71, 0x94, 0xe0, 0x9b, 0x72,
-	0xba, 0x10, 0x2a, 0x9e, 0x94, 0xdd, 0x33, 0x3c, 0x29, 0xef, 0x40, 0x33, 0x26, 0x0f, 0xe5, 0xc1,
-	0xee, 0x96, 0xfc, 0xc6, 0x73, 0xd4, 0xeb, 0x00, 0x3c, 0x95, 0xe9, 0x76, 0x22, 0x05, 0xae, 0x7b,
-	0xbc, 0x94, 0xce, 0xf9, 0x96, 0xbd, 0x0c, 0x4d, 0xfc, 0xec, 0xca, 0x3f, 0x7d, 0x1c, 0xde, 0x40,
-	0xf1, 0x19, 0xf9, 0x93, 0x00, 0x4a, 0x7b, 0x9b, 0x6c, 0x5c, 0x54, 0xbc, 0x12, 0x47, 0x72, 0xeb,
-	0xaf, 0x1a, 0xc0, 0xb2, 0x30, 0x32, 0x80, 0xc6, 0xbb, 0xf0, 0x30, 0x0a, 0xe7, 0xfa, 0x13, 0x0d,
-	0x6b, 0xa1, 0x46, 0xfb, 0x35, 0x92, 0x13, 0x31, 0x37, 0xb2, 0x85, 0x35, 0x79, 0x98, 0x19, 0xc9,
-	0x66, 0x5d, 0x68, 0xed, 0x8a, 0xd4, 0x88, 0x2e, 0x92, 0xb1, 0x82, 0x19, 0xb9, 0x8f, 0x32, 0x56,
-	0x2b, 0x23, 0xaf, 0xeb, 0xc9, 0xa2, 0xd8, 0xc8, 0xbf, 0x6c, 0xed, 0x42, 0x43, 0xbf, 0x89, 0x58,
-	0x0b, 0x1c, 0xfd, 0x51, 0xb8, 0xc6, 0x1a, 0x60, 0xbd, 0x8c, 0xfa, 0x35, 0x6c, 0x0c, 0x68, 0x3c,
-	0xcc, 0x44, 0xdf, 0xc2, 0x85, 0xde, 0x04, 0x22, 0x9c, 0xa0, 0xa6, 0x5f, 0xa7, 0x5d, 0x88, 0xe0,
-	0x49, 0xf0, 0x42, 0x44, 0x7d, 0x7b, 0x6b, 0x5b, 0xf7, 0x09, 0x9a, 0xa8, 0x03, 0xee, 0xcb, 0xc0,
-	0xf0, 0xd6, 0xf0, 0x64, 0xbf, 0x66, 0x34, 0xae, 0xe1, 0x78, 0x3b, 0xa4, 0xb1, 0xc5, 0x2e, 0x40,
-	0xfb, 0x6d, 0x2c, 0xc7, 0x81, 0x98, 0xe9, 0x09, 0xb7, 0xbe, 0x83, 0x76, 0xa1, 0xce, 0x2c, 0x3e,
-	0x54, 0xdf, 0xa6, 0x22, 0xc1, 0x0f, 0xd7, 0x8b, 0xd0, 0x25, 0x79, 0x27, 0x0a, 0xd3, 0x20, 0xcc,
-	0x64, 0xbf, 0x76, 0xd0, 0xa0, 0x00, 0x3f, 0xfc, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x73, 0x41, 0xd5,
-	0x2f, 0xee, 0x0f, 0x00, 0x00,
+	// 2233 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x4f, 0x73, 0xdb, 0xc6,
+	0x15, 0x37, 0x40, 0x80, 0x04, 0x1f, 0x49, 0x19, 0xde, 0x64, 0x1a, 0xa6, 0x49, 0x2a, 0x19, 0x8d,
+	0x1d, 0x45, 0x71, 0xdd, 0xc6, 0xe9, 0x34, 0x99, 0x5c, 0x5a, 0x47, 0x56, 0x25, 0x37, 0xfe, 0x23,
+	0xaf, 0xe5, 0x7a, 0x26, 0x3d, 0x60, 0x56, 0xc4, 0x8a, 0x44, 0x4d, 0x01, 0x30, 0x16, 0x10, 0xa3,
+	0x1e, 0x3a, 0x9d, 0x7e, 0x84, 0xde, 0x7a, 0xe8, 0x47, 0xe8, 0xa5, 0x87, 0xde, 0xfa, 0x11, 0xfa,
+	0x31, 0xfa, 0x15, 0x7a, 0xee, 0xbc, 0xb7, 0x0b, 0x10, 0xa0, 0xc9, 0xda, 0xe3, 0x0b, 0xb9, 0xef,
+	0xbd, 0xdf, 0xfe, 0x7b, 0xff, 0xb1, 0x30, 0x3a, 0x17, 0xb3, 0xdf, 0xa7, 0xc9, 0xf4, 0x76, 0x96,
+	0xa7, 0x45, 0xca, 0x5c, 0xfa, 0x0b, 0x4e, 0xc1, 0x7b, 0x90, 0x4e, 0xe3, 0x84, 0xcb, 0x97, 0xcc,
+	0x87, 0x4e, 0x19, 0x47, 0x63, 0x6b, 0xc7, 0xda, 0x75, 0x38, 0x0e, 0xd9, 0x0f, 0xa0, 0x9b, 0x09,
+	0xa5, 0x16, 0xd1, 0xd8, 0xde, 0xb1, 0x76, 0xfb, 0xdc, 0x50, 0x8c, 0x81, 0x93, 0x88, 0x73, 0x39,
+	0xee, 0x10, 0x97, 0xc6, 0x6c, 0x0c, 0xbd, 0x0b, 0x99, 0xab, 0x38, 0x4d, 0xc6, 0xce, 0x8e, 0xb5,
+	0xeb, 0xf2, 0x8a, 0x0c, 0xfe, 0x65, 0x55, 0x9b, 0xa8, 0x8c, 0xbd, 0x0f, 0x9e, 0xcc, 0xf3, 0x70,
+	0x92, 0x46, 0x92, 0x76, 0x72, 0x79, 0x4f, 0xe6, 0xf9, 0x7e, 0x1a, 0x49, 0xf6, 0x1e, 0xe0, 0x30,
+	0x3c, 0x57, 0xd3, 0x6a, 0x3b, 0x99, 0xe7, 0x0f, 0xd5, 0x94, 0x5d, 0x87, 0x61, 0x22, 0x65, 0x14,
+	0xe6, 0x72, 0x92, 0x5e, 0xc8, 0x9c, 0xb6, 0xf5, 0xf8, 0x00, 0x79, 0x5c, 0xb3, 0x36, 0xef, 0xce,
+	0xb6, 0x61, 0x70, 0x1e, 0x27, 0x61, 0x25, 0x75, 0x49, 0x0a, 0xe7, 0x71, 0xf2, 0xdb, 0x06, 0x40,
+	0x7c, 0x5f, 0x03, 0xba, 0x06, 0x20, 0xbe, 0x37, 0x80, 0xe0, 0xdf, 0x36, 0x6c, 0xed, 0xe7, 0x52,
+	0x14, 0xf2, 0x44, 0x9c, 0xce, 0x25, 0xaa, 0x8a, 0x81, 0x53, 0x5c, 0x66, 0xd5, 0x0d, 0x68, 0x8c,
+	0x3c, 0x91, 0x4b, 0x41, 0x67, 0x77, 0x39, 0x8d, 0xd9, 0x47, 0x00, 0xd9, 0x5c, 0x5c, 0xca, 0x3c,
+	0x4c, 0xca, 0x73, 0x3a, 0xb7, 0xcb, 0xfb, 0x9a, 0xf3, 0xa8, 0x3c, 0x67, 0xfb, 0x30, 0xcc, 0xd3,
+	0xd3, 0xb4, 0x08, 0xe7, 0xf2, 0x42, 0xce, 0xd5, 0xd8, 0xd9, 0xe9, 0xec, 0x6e, 0xdd, 0xd9, 0xd1,
+	0x26, 0xba, 0xdd, 0xde, 0xf3, 0x36, 0x47, 0xe4, 0x03, 0x04, 0xf2, 0x41, 0x5e, 0x8f, 0x15, 0x6a,
+	0x14, 0xf7, 0x0a, 0x95, 0x2c, 0xe8, 0x76, 0x1e, 0xef, 0x21, 0xfd, 0x54, 0x16, 0xc1, 0x67, 0xd0,
+	0xa7, 0xe9, 0x27, 0x78, 0xbe, 0x2d, 0x00, 0xbd, 0x16, 0xce, 0xf5, 0xaf, 0xd4, 0xf4, 0xa3, 0xf4,
+	0x5c, 0xcc, 0x7d, 0x2b, 0x98, 0x02, 0x2c, 0xb7, 0x60, 0x3e, 0x0c, 0x89, 0xba, 0x27, 0xcf, 0x44,
+	0x39, 0x47, 0xfc, 0x55, 0x18, 0x10, 0x87, 0x8b, 0x24, 0x4a, 0xcf, 0x7d, 0x8b, 0x5d, 0x83, 0x11,
+	0x31, 0xbe, 0x11, 0x4a, 0xce, 0xe3, 0x44, 0xfa, 0x76, 0x8d, 0x39, 0xcc, 0xa5, 0x8c, 0x2e, 0xfd,
+	0x0e, 0x63, 0xb0, 0x55, 0x2d, 0x23, 0x13, 0x15, 0x5f, 0x48, 0xdf, 0x09, 0xc2, 0xb6, 0x3a, 0xdf,
+	0xd2, 0x29, 0xde, 0x07, 0xaf, 0xc0, 0xf9, 0x61, 0x1c, 0x91, 0x62, 0x47, 0xbc, 0x47, 0xf4, 0xfd,
+	0x28, 0xf8, 0x14, 0x86, 0xbf, 0x49, 0xe3, 0xa4, 0xb6, 0x56, 0x13, 0x6a, 0xb7, 0xa1, 0x27, 0x4d,
+	0xe8, 0x5b, 0x9e, 0xc4, 0x87, 0x4e, 0x96, 0x2a, 0x63, 0x5d, 0x1c, 0x06, 0x7f, 0xee, 0x40, 0xff,
+	0x71, 0x26, 0x73, 0x51, 0xe0, 0xf6, 0x37, 0x1a, 0xce, 0xb2, 0x75, 0xe7, 0x9a, 0xb1, 0xae, 0x96,
+	0xa3, 0x65, 0x8c, 0xff, 0xec, 0x42, 0x2f, 0x92, 0x62, 0xce, 0xe5, 0x4b, 0x5a, 0x7f, 0x70, 0x67,
+	0xcb, 0x20, 0xef, 0x69, 0x2e, 0xaf, 0xc4, 0x84, 0xcc, 0xc5, 0x02, 0x91, 0x9d, 0x36, 0x52, 0x73,
+	0x79, 0x25, 0x66, 0x01, 0xb8, 0xb3, 0x12, 0x71, 0x0e, 0xe1, 0x86, 0x06, 0x77, 0x84, 0x3c, 0xae,
+	0x45, 0xec, 0x06, 0x74, 0x25, 0x1d, 0x94, 0xbc, 0x67, 0x70, 0x67, 0x64, 0x40, 0x07, 0xc4, 0xe4,
+	0x46, 0x88, 0x9b, 0x66, 0x69, 0x32, 0x45, 0x5c, 0xb7, 0xb5, 0xe9, 0xb1, 0xe6, 0xf2, 0x4a, 0x8c,
+	0xc8, 0xa9, 0xd0, 0xc8, 0x5e, 0x0b, 0x79, 0x28, 0x0c, 0x72, 0x2a, 0x6a, 0x64, 0x94, 0xa7, 0x19,
+	0x22, 0xbd, 0x95, 0x8b, 0xa4, 0x99, 0xb9, 0x08, 0x0d, 0xd8, 0xe7, 0x00, 0x51, 0x9c, 0x4c, 0x9f,
+	0x94, 0x68, 0xd0, 0x71, 0x9f, 0xc0, 0x95, 0x26, 0xef, 0xd5, 0x02, 0xde, 0x00, 0x05, 0xff, 0x5c,
+	0x1a, 0xe1, 0x2d, 0x0d, 0x5b, 0x19, 0xae, 0xf3, 0x66, 0x86, 0x53, 0xd9, 0xd8, 0x69, 0xdf, 0x42,
+	0x73, 0x79, 0x25, 0xae, 0x0d, 0xa7, 0xb2, 0xb1, 0xdb, 0x46, 0x6a, 0x2e, 0xaf, 0xc4, 0xc6, 0x70,
+	0x2a, 0x33, 0xba, 0x6e, 0x18, 0x4e, 0x65, 0x5c, 0x8b, 0x2a, 0xc3, 0xa9, 0x6c, 0xdc, 0x7b, 0xc5,
+	0x70, 0x2a, 0xe3, 0x46, 0x58, 0x1b, 0x4e, 0x65, 0x63, 0xef, 0x55, 0xc3, 0xe1, 0xa6, 0x46, 0x5c,
+	0x1b, 0x4e, 0x65, 0xe3, 0x7e, 0x0b, 0x79, 0x28, 0x0c, 0x72, 0x2a, 0x6a, 0x24, 0x59, 0x46, 0x65,
+	0x63, 0x78, 0xd5, 0x70, 0xfa, 0x22, 0x34, 0x68, 0x1a, 0x4e, 0x65, 0xe3, 0xc1, 0x5a, 0xc3, 0xa9,
+	0x8c, 0x37, 0x40, 0xc1, 0x7f, 0x2d, 0xe8, 0x19, 0x9f, 0x5f, 0x53, 0x93, 0xde, 0x05, 0x77, 0x22,
+	0xf2, 0x48, 0x8d, 0xed, 0x9d, 0xce, 0xae, 0xcb, 0x35, 0x81, 0xe6, 0x3d, 0x13, 0x49, 0x88, 0x84,
+	0x09, 0xc4, 0xde, 0x99, 0x48, 0xf6, 0x45, 0x1e, 0xa1, 0x68, 0x56, 0x1a, 0x91, 0xa9, 0x0d, 0xb3,
+	0x52, 0x8b, 0xc6, 0xd0, 0x3b, 0x9b, 0xa7, 0x0b, 0x99, 0xab, 0xb1, 0x4b, 0xab, 0x55, 0x24, 0x56,
+	0x3e, 0x34, 0x9a, 0xcc, 0xc9, 0x00, 0x0e, 0x37, 0x14, 0xfb, 0x00, 0xfa, 0x4a, 0x8a, 0x22, 0x5c,
+	0xc4, 0x49, 0x44, 0x6a, 0x77, 0xb9, 0x87, 0x8c, 0xe7, 0x71, 0x12, 0x61, 0xb6, 0xcf, 0xd3, 0x32,
+	0x89, 0xb4, 0xd4, 0xd3, 0xd9, 0x9e, 0x38, 0x24, 0xde, 0x86, 0xc1, 0x3c, 0x16, 0x49, 0xf8, 0x87,
+	0x59, 0x29, 0x92, 0x29, 0xa9, 0xd8, 0xe5, 0x80, 0xac, 0xef, 0x88, 0x13, 0xf4, 0xcd, 0xbd, 0x55,
+	0x16, 0x7c, 0x09, 0x3d, 0x13, 0xcc, 0x58, 0x57, 0xe8, 0xec, 0xa6, 0xd6, 0x4c, 0x56, 0x0e, 0x6e,
+	0xb7, 0x0e, 0x1e, 0xf4, 0xcd, 0x44, 0x95, 0x05, 0x1c, 0xdc, 0xa3, 0x72, 0xd3, 0x0a, 0xd7, 0x8d,
+	0x6f, 0xdb, 0xe4, 0xdb, 0xa3, 0xda, 0xbf, 0x1a, 0x7e, 0xcd, 0xc0, 0x99, 0xa7, 0x4a, 0xbb, 0xbf,
+	0xc3, 0x69, 0x1c, 0x9c, 0xd2, 0x9a, 0x2a, 0x63, 0x5b, 0x60, 0xa7, 0x2f, 0x68, 0x45, 0x8f, 0xdb,
+	0xe9, 0x8b, 0x7a, 0x0f, 0x7b, 0xcd, 0x1e, 0x9d, 0xd7, 0xef, 0xe1, 0x34, 0xf6, 0xf8, 0x25, 0x74,
+	0x0e, 0x44, 0x81, 0xaa, 0x9e, 0x89, 0x24, 0x0a, 0xcd, 0xd1, 0xf1, 0x96, 0x1e, 0x32, 0xc8, 0x72,
+	0x1f, 0x40, 0x7f, 0x21, 0x2e, 0x64, 0x68, 0xf6, 0x24, 0x21, 0x32, 0x50, 0x18, 0xdc, 0x84, 0xae,
+	0x4e, 0x5e, 0xec, 0x43, 0xe8, 0x48, 0x51, 0xd0, 0xec, 0xc1, 0x1d, 0x68, 0xc4, 0x07, 0xb2, 0x83,
+	0x5f, 0x68, 0xdc, 0x9a, 0xdb, 0x98, 0x79, 0x3a, 0x0f, 0xbf, 0x32, 0xef, 0x23, 0xe8, 0x99, 0xa4,
+	0xb7, 0x4e, 0xb5, 0xc1, 0x4f, 0x8c, 0xf8, 0xcd, 0xb4, 0x14, 0xdc, 0x05, 0x07, 0xe3, 0x6b, 0xe9,
+	0xd8, 0x56, 0xd3, 0xb1, 0x7f, 0xdc, 0xb2, 0xd3, 0xd5, 0x46, 0x40, 0x2e, 0xb5, 0x18, 0xec, 0x41,
+	0xcf, 0xe4, 0x56, 0xb6, 0x0d, 0x0e, 0x06, 0xa9, 0xb9, 0xf2, 0xa0, 0x19, 0xc0, 0x24, 0x08, 0xbe,
+	0x36, 0xd8, 0x35, 0xa7, 0xab, 0xe6, 0xea, 0x6b, 0xaf, 0x99, 0xfb, 0x11, 0x3a, 0x97, 0x4e, 0xc8,
+	0xeb, 0x6e, 0xf2, 0xb1, 0x11, 0xeb, 0x74, 0x1b, 0xc5, 0xaa, 0x15, 0x8f, 0x51, 0xac, 0xc8, 0x3a,
+	0x3b, 0x00, 0xcb, 0x8c, 0x8d, 0xeb, 0xa8, 0x32, 0x2e, 0x2a, 0x05, 0xe2, 0xb8, 0x89, 0x50, 0xd9,
+	0x5a, 0x04, 0x07, 0xe7, 0xa9, 0x14, 0x45, 0x95, 0x1e, 0xec, 0x65, 0x7a, 0x58, 0xd7, 0x9a, 0x9a,
+	0x02, 0xed, 0xd4, 0x05, 0x1a, 0x51, 0x14, 0xa3, 0xba, 0x1b, 0xa4, 0x71, 0xf0, 0x47, 0xf0, 0x9f,
+	0x29, 0x99, 0xd7, 0xed, 0x80, 0x29, 0xed, 0x85, 0x49, 0x3f, 0x23, 0x8e, 0x43, 0x76, 0x1d, 0x5c,
+	0x8c, 0x77, 0x1d, 0x77, 0x4b, 0x25, 0xe1, 0x69, 0xb8, 0x96, 0x34, 0x72, 0x47, 0xa7, 0x95, 0x3b,
+	0xda, 0xe9, 0xc1, 0x59, 0x49, 0x0f, 0xc1, 0x3f, 0x2c, 0x70, 0x9e, 0x8b, 0x0b, 0xb9, 0xc1, 0x11,
+	0x3e, 0x37, 0x1e, 0xdf, 0xf0, 0x86, 0x77, 0xcd, 0xe6, 0x38, 0x8b, 0x7e, 0xc8, 0x25, 0xbc, 0x85,
+	0x19, 0xb1, 0x5b, 0xd0, 0x47, 0xb3, 0x85, 0x8d, 0x20, 0x7c, 0xc5, 0x81, 0xbc, 0xa9, 0x19, 0x05,
+	0x5f, 0x80, 0x57, 0xad, 0xc1, 0x06, 0xd0, 0x3b, 0x10, 0x05, 0x92, 0xfe, 0x15, 0x36, 0x04, 0x0f,
+	0xfd, 0x99, 0x28, 0x0b, 0xa9, 0x43, 0x61, 0x28, 0x3b, 0xf8, 0x8f, 0x5d, 0x15, 0x59, 0xa3, 0xae,
+	0x95, 0x6c, 0x7d, 0xa3, 0xe5, 0xbe, 0x1b, 0x4b, 0x68, 0x00, 0x0e, 0x2a, 0x69, 0xb5, 0x9d, 0x31,
+	0xf5, 0x93, 0x64, 0x84, 0xc9, 0xc5, 0x62, 0xb5, 0xc6, 0x9a, 0xca, 0x49, 0x32, 0xf6, 0x21, 0xd8,
+	0xb3, 0xd2, 0xd4, 0xd6, 0x76, 0xcd, 0xb4, 0x67, 0x25, 0xdb, 0xd6, 0x51, 0xdd, 0x5d, 0x57, 0x2d,
+	0x51, 0x82, 0x5b, 0x60, 0x2d, 0x5c, 0x69, 0x5b, 0xaa, 0x3a, 0x49, 0x32, 0xc4, 0x50, 0x90, 0x78,
+	0x6b, 0x2b, 0x24, 0xc9, 0xf4, 0x51, 0xd3, 0xd5, 0x2a, 0x5a, 0xd5, 0x46, 0x92, 0xb1, 0x5b, 0x18,
+	0x21, 0xc9, 0x34, 0x7c, 0x59, 0x4a, 0x53, 0x43, 0xd7, 0x94, 0xc5, 0x9e, 0x29, 0x8b, 0xc1, 0x57,
+	0xb0, 0x45, 0x4e, 0xb9, 0xec, 0x2a, 0x6f, 0xb6, 0xba, 0x4a, 0x66, 0xe6, 0x36, 0x41, 0x3a, 0x37,
+	0x1c, 0xb5, 0x67, 0xaa, 0x0c, 0x67, 0x9e, 0xbc, 0x66, 0xa6, 0xf9, 0x60, 0xc0, 0xf4, 0x60, 0x57,
+	0xe9, 0x21, 0xf8, 0xae, 0xb5, 0xd2, 0x7a, 0x7b, 0xdf, 0x6c, 0xd9, 0x7b, 0xe3, 0xa9, 0x70, 0xed,
+	0xc7, 0xdf, 0x9a, 0x0f, 0x39, 0xfb, 0xf1, 0xb7, 0xc1, 0xdf, 0x2d, 0xe8, 0x3d, 0x17, 0xf3, 0x39,
+	0xae, 0xca, 0xc0, 0x89, 0xe2, 0x89, 0x34, 0xee, 0x4f, 0x63, 0x8c, 0x9d, 0xd3, 0x5c, 0x8a, 0x17,
+	0xe1, 0x42, 0xcc, 0xe7, 0x26, 0xe9, 0xf4, 0x89, 0x83, 0xb3, 0xb0, 0x1c, 0x68, 0xf1, 0xb2, 0x11,
+	0xf7, 0x88, 0x71, 0x9c, 0x2a, 0x8c, 0xa7, 0x22, 0x2d, 0xc4, 0xdc, 0x84, 0x9c, 0x26, 0x70, 0xc5,
+	0x79, 0x7c, 0x21, 0x43, 0xf4, 0x9b, 0xea, 0xb3, 0xb0, 0x8f, 0x1c, 0xf4, 0xa8, 0x04, 0xc5, 0x91,
+	0x14, 0x91, 0x11, 0xeb, 0x8f, 0xc2, 0x3e, 0x72, 0x48, 0x1c, 0x1c, 0x40, 0xef, 0x5e, 0x3c, 0xa1,
+	0x1c, 0xb1, 0x0c, 0x77, 0xab, 0x15, 0xee, 0x01, 0x38, 0xf5, 0x61, 0x97, 0x4e, 0x60, 0x2e, 0xc9,
+	0x49, 0x16, 0x7c, 0x09, 0xfd, 0x5f, 0x53, 0xe1, 0x5e, 0xaf, 0xcd, 0xcd, 0x65, 0xfe, 0xe7, 0x00,
+	0xc7, 0xb9, 0x7c, 0x1e, 0xeb, 0x3e, 0x66, 0x5d, 0x81, 0xaf, 0x12, 0xa1, 0xbd, 0x4c, 0x84, 0xc1,
+	0x2d, 0xe8, 0x1e, 0xa7, 0x6a, 0xfd, 0x5e, 0x26, 0x49, 0xda, 0xcb, 0xaf, 0x98, 0xbf, 0xb8, 0xd0,
+	0x3d, 0xa6, 0x6f, 0xd5, 0x37, 0x6e, 0xc3, 0xae, 0x83, 0x8b, 0xd9, 0x07, 0x6d, 0xd0, 0xcc, 0x8e,
+	0x98, 0x2c, 0xb8, 0x96, 0x90, 0x62, 0xf3, 0x34, 0x0b, 0xf5, 0x6c, 0x87, 0x66, 0xf7, 0x91, 0xb3,
+	0x5f, 0x35, 0x72, 0xf4, 0xad, 0x3f, 0x2b, 0x93, 0xaa, 0x27, 0x43, 0xfa, 0xa8, 0x4c, 0xd8, 0xa7,
+	0x70, 0xad, 0x12, 0x85, 0x8b, 0xb8, 0x98, 0x85, 0xf2, 0x52, 0x8e, 0xbb, 0x84, 0xd9, 0x32, 0x98,
+	0xe7, 0x71, 0x31, 0x3b, 0xb8, 0x94, 0xec, 0x63, 0xd8, 0x8a, 0x55, 0x48, 0xe8, 0x32, 0x8b, 0x44,
+	0x21, 0xc7, 0xbd, 0x9d, 0xce, 0xae, 0xc7, 0x87, 0xb1, 0x7a, 0x24, 0x65, 0xf4, 0x8c, 0x78, 0xec,
+	0x2e, 0x0c, 0xb3, 0x5c, 0x2e, 0xe2, 0xc4, 0x1c, 0xc6, 0xa3, 0x43, 0xff, 0xa8, 0x0a, 0x7b, 0xba,
+	0xfa, 0xed, 0x63, 0x42, 0xd0, 0xe1, 0x0e, 0x92, 0x22, 0xbf, 0xe4, 0x83, 0x6c, 0xc9, 0x61, 0xdb,
+	0x5a, 0x6b, 0xfd, 0x9d, 0x4e, 0x23, 0xa5, 0x68, 0x1d, 0xeb, 0x4a, 0xd3, 0xec, 0x3e, 0xa1, 0xdd,
+	0x7d, 0x7e, 0x00, 0xfd, 0x89, 0x48, 0x26, 0x72, 0x1e, 0xce, 0x4a, 0xea, 0x8c, 0x3d, 0xee, 0x69,
+	0xc6, 0x51, 0x89, 0x1a, 0xc7, 0xcc, 0x30, 0xd4, 0xe6, 0x78, 0x59, 0xca, 0xa6, 0x33, 0x8c, 0x36,
+	0x35, 0xab, 0x5b, 0x9b, 0x9b, 0xd5, 0xab, 0xff, 0xb7, 0x59, 0xf5, 0x5f, 0xd3, 0xac, 0x5e, 0x5b,
+	0x6d, 0x56, 0x6b, 0xf7, 0x66, 0x9b, 0xdd, 0xfb, 0x87, 0x4f, 0xc0, 0x5f, 0x55, 0x1f, 0x5e, 0xec,
+	0x85, 0xbc, 0x34, 0xae, 0x8a, 0x43, 0xf6, 0x09, 0xb8, 0x17, 0x62, 0x5e, 0xca, 0xb1, 0xdd, 0x4a,
+	0x83, 0x4b, 0xff, 0xe6, 0x5a, 0xfe, 0xb5, 0xfd, 0x95, 0x15, 0x0c, 0xa0, 0xcf, 0xe5, 0xe4, 0x22,
+	0xcd, 0xf1, 0x13, 0x6f, 0x56, 0x13, 0x6b, 0xbe, 0xf0, 0x46, 0x6f, 0xf0, 0x85, 0xf7, 0x09, 0xf4,
+	0xf4, 0x6b, 0x4c, 0xe5, 0xb1, 0xa3, 0x96, 0xf1, 0x79, 0x25, 0x0d, 0x86, 0x00, 0x87, 0xb2, 0xb8,
+	0x9b, 0x4b, 0x81, 0xfb, 0xfe, 0xcd, 0x5a, 0x92, 0x6f, 0xf9, 0x6d, 0xf9, 0x1e, 0xd0, 0x2b, 0x4d,
+	0xf5, 0x7a, 0xe1, 0xf2, 0x2e, 0x92, 0xf7, 0xc9, 0x2b, 0x48, 0x40, 0xc1, 0xeb, 0xd0, 0x1c, 0x7a,
+	0xdf, 0x79, 0x84, 0x9d, 0xcc, 0x0d, 0x70, 0x71, 0xac, 0x3f, 0x57, 0x06, 0x75, 0x35, 0xc7, 0x83,
+	0xdc, 0x4f, 0xce, 0x52, 0xae, 0xa5, 0xc1, 0x4f, 0x61, 0x78, 0x24, 0x45, 0x5e, 0x9c, 0x9a, 0x6f,
+	0xf7, 0x6d, 0x18, 0x4c, 0xe6, 0xb1, 0x4c, 0x8a, 0xb0, 0x88, 0xcf, 0xf5, 0x19, 0x3b, 0x1c, 0x34,
+	0xeb, 0x24, 0x3e, 0x97, 0xc1, 0x71, 0x73, 0x82, 0xca, 0x5e, 0x3b, 0x01, 0x01, 0x4a, 0xe6, 0x17,
+	0x32, 0xd7, 0x00, 0x5b, 0x03, 0x34, 0xcb, 0xac, 0x78, 0x55, 0xeb, 0xf0, 0x69, 0x21, 0x8a, 0x72,
+	0x43, 0xce, 0xf9, 0x0c, 0xba, 0x8a, 0xc4, 0xa6, 0x5e, 0xbc, 0xd3, 0xd2, 0xbe, 0x9e, 0xc9, 0x0d,
+	0x24, 0xf8, 0x10, 0xe0, 0x24, 0x2f, 0x55, 0x21, 0xa9, 0x6f, 0xc4, 0xe2, 0x94, 0xd4, 0xbd, 0x6b,
+	0x12, 0x1c, 0x2f, 0xa5, 0x6f, 0x69, 0x11, 0xbd, 0x62, 0xa7, 0x5e, 0x51, 0x80, 0x57, 0xe9, 0xb5,
+	0x69, 0x2d, 0x6b, 0xb3, 0xb5, 0xec, 0x15, 0x6b, 0x6d, 0xc3, 0x60, 0xf9, 0xfa, 0xa7, 0x3d, 0xcc,
+	0xe5, 0x50, 0x3f, 0xff, 0xa9, 0xbd, 0xbf, 0x5a, 0x00, 0xcb, 0x5e, 0x88, 0x01, 0x74, 0x9f, 0x25,
+	0x2f, 0xd2, 0x64, 0xa1, 0x5f, 0xe7, 0xb0, 0xfd, 0xd1, 0x52, 0xdf, 0x22, 0x3a, 0x17, 0x0b, 0x43,
+	0xdb, 0xd8, 0x86, 0x1d, 0x95, 0x86, 0x72, 0xd8, 0x08, 0xfa, 0x07, 0xa2, 0x30, 0xa4, 0x87, 0x60,
+	0x6c, 0x5a, 0x0c, 0xed, 0x23, 0x7d, 0x28, 0x6a, 0x7a, 0x47, 0x2f, 0x96, 0x66, 0x86, 0xfe, 0x15,
+	0x63, 0x30, 0x32, 0x4d, 0x87, 0x61, 0xfd, 0xc9, 0xda, 0x3b, 0x80, 0xae, 0xfe, 0x52, 0x63, 0x7d,
+	0x70, 0xf5, 0x1b, 0xe1, 0x15, 0xd6, 0x05, 0xfb, 0x61, 0xea, 0x5b, 0xd8, 0x1f, 0xe2, 0x82, 0x47,
+	0xa5, 0xf0, 0x6d, 0xdc, 0xfc, 0x49, 0x2c, 0x92, 0x29, 0x72, 0xfc, 0x0e, 0x9d, 0x4c, 0xc4, 0xf7,
+	0xe2, 0x07, 0x22, 0xf5, 0x9d, 0xbd, 0xbb, 0xba, 0x5d, 0xa4, 0x85, 0x86, 0xe0, 0x3d, 0x8c, 0x0d,
+	0xee, 0x0a, 0xde, 0xf6, 0x9b, 0x92, 0xc6, 0x16, 0x8e, 0xef, 0x26, 0x34, 0xa6, 0x37, 0xc4, 0xa7,
+	0x99, 0x9c, 0xc4, 0x62, 0xae, 0x17, 0xdc, 0xfb, 0x19, 0x0c, 0x1a, 0x0d, 0x44, 0xfd, 0x6e, 0xf9,
+	0xb4, 0x10, 0x39, 0xbe, 0x4b, 0x5e, 0x83, 0x11, 0xd1, 0xfb, 0x69, 0x52, 0xc4, 0x49, 0x29, 0x7d,
+	0x6b, 0xef, 0x77, 0x30, 0x6c, 0xba, 0x10, 0x3e, 0x66, 0xea, 0xd1, 0xe3, 0x84, 0x1e, 0x2a, 0x69,
+	0x92, 0xe1, 0x9c, 0x9d, 0x11, 0xcb, 0x5a, 0xb2, 0x8c, 0x1f, 0xf9, 0x36, 0x7b, 0x07, 0xae, 0x6a,
+	0x16, 0x3e, 0x33, 0x27, 0x89, 0x9c, 0x14, 0x7e, 0xe7, 0xb4, 0x4b, 0x3e, 0xfa, 0xc5, 0xff, 0x06,
+	0x00, 0xb8, 0x5e, 0x91, 0x4b, 0x51, 0x17, 0x00, 0x00,
 }
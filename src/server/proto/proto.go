@@ -3,39 +3,44 @@ package proto
 import (
 	"errors"
 	"fmt"
-	"github.com/jxbdlut/leaf/network/protobuf"
 	"server/utils"
 	"strings"
 )
 
 var (
-	Processor   = protobuf.NewProcessor()
-	GangTypeMap = map[GangType]string{GangType_MingGang: "明杠", GangType_BuGang: "补杠", GangType_AnGang: "暗杠"}
-	HuTypeMap   = map[HuType]string{HuType_Nomal: "平胡", HuType_Mo: "自摸", HuType_GangHua: "杠上花", HuType_QiangGang: "抢杠", HuType_HaiDiLao: "海底捞"}
-	WaveTypeMap = map[Wave_WaveType]string{Wave_EatWave: "吃", Wave_PongWave: "碰", Wave_GangWave: "杠"}
+	Processor       = NewEnvelopeProcessor(CodecProtobuf, true)
+	JSONProcessor   = NewEnvelopeProcessor(CodecJSON, true)
+	GangTypeMap     = map[GangType]string{GangType_MingGang: "明杠", GangType_BuGang: "补杠", GangType_AnGang: "暗杠"}
+	HuTypeMap       = map[HuType]string{HuType_Nomal: "平胡", HuType_Mo: "自摸", HuType_GangHua: "杠上花", HuType_QiangGang: "抢杠", HuType_HaiDiLao: "海底捞"}
+	WaveTypeMap     = map[Wave_WaveType]string{Wave_EatWave: "吃", Wave_PongWave: "碰", Wave_GangWave: "杠"}
+	PlayerStatusMap = map[PlayerStatus]string{PlayerStatus_StatusOnline: "在线", PlayerStatus_StatusOffline: "掉线",
+		PlayerStatus_StatusTrustee: "托管", PlayerStatus_StatusReconnect: "重连"}
 )
 
 func init() {
-	Processor.Register(&LoginReq{})
-	Processor.Register(&LoginRsp{})
-	Processor.Register(&CreateTableReq{})
-	Processor.Register(&CreateTableRsp{})
-	Processor.Register(&JoinTableReq{})
-	Processor.Register(&JoinTableRsp{})
-	Processor.Register(&UserJoinTableMsg{})
-	Processor.Register(&OperatReq{})
-	Processor.Register(&OperatRsp{})
-	Processor.Register(&OperatMsg{})
-	Processor.Register(&TableOperatReq{})
-	Processor.Register(&TableOperatRsp{})
-	Processor.Register(&TableOperatMsg{})
-
-	//Processor.Range(printRegistedMsg)
-}
-
-//func printRegistedMsg(id uint16, t reflect.Type) {
-//	log.Debug("id:%v, type:%v", id, t)
-//}
+	RegisterCmd(Cmd_CmdLoginReq, "login", &LoginReq{})
+	RegisterCmd(Cmd_CmdLoginRsp, "login", &LoginRsp{})
+	RegisterCmd(Cmd_CmdCreateTableReq, "game", &CreateTableReq{})
+	RegisterCmd(Cmd_CmdCreateTableRsp, "game", &CreateTableRsp{})
+	RegisterCmd(Cmd_CmdJoinTableReq, "game", &JoinTableReq{})
+	RegisterCmd(Cmd_CmdJoinTableRsp, "game", &JoinTableRsp{})
+	RegisterCmd(Cmd_CmdUserJoinTableMsg, "game", &UserJoinTableMsg{})
+	RegisterCmd(Cmd_CmdOperatReq, "game", &OperatReq{})
+	RegisterCmd(Cmd_CmdOperatRsp, "game", &OperatRsp{})
+	RegisterCmd(Cmd_CmdOperatMsg, "game", &OperatMsg{})
+	RegisterCmd(Cmd_CmdTableOperatReq, "game", &TableOperatReq{})
+	RegisterCmd(Cmd_CmdTableOperatRsp, "game", &TableOperatRsp{})
+	RegisterCmd(Cmd_CmdTableOperatMsg, "game", &TableOperatMsg{})
+	RegisterCmd(Cmd_CmdFlowerMsg, "game", &FlowerMsg{})
+	RegisterCmd(Cmd_CmdDiceMsg, "game", &DiceMsg{})
+	RegisterCmd(Cmd_CmdHeartbeatReq, "game", &HeartbeatReq{})
+	RegisterCmd(Cmd_CmdHeartbeatRsp, "game", &HeartbeatRsp{})
+	RegisterCmd(Cmd_CmdPlayerStatusMsg, "game", &PlayerStatusMsg{})
+	RegisterCmd(Cmd_CmdTrusteeReq, "game", &TrusteeReq{})
+	RegisterCmd(Cmd_CmdTrusteeRsp, "game", &TrusteeRsp{})
+	RegisterCmd(Cmd_CmdGetAreaReq, "game", &GetAreaReq{})
+	RegisterCmd(Cmd_CmdGetAreaRsp, "game", &GetAreaRsp{})
+}
 
 func GangTypeStr(gangType GangType) string {
 	return GangTypeMap[gangType]
@@ -113,6 +118,7 @@ func NewOperatReq() *OperatReq {
 	req.EatReq = new(EatReq)
 	req.GangReq = new(GangReq)
 	req.DropReq = new(DropReq)
+	req.DingQueReq = new(DingQueReq)
 	return req
 }
 
@@ -139,6 +145,9 @@ func (m *OperatReq) Info() string {
 	if m.Type&OperatType_DropOperat != 0 {
 		result = append(result, "出牌:"+m.DropReq.Info())
 	}
+	if m.Type&OperatType_DingQueOperat != 0 {
+		result = append(result, "定缺:"+m.DingQueReq.Info())
+	}
 	if len(result) == 0 {
 		return "req type err"
 	}
@@ -147,16 +156,38 @@ func (m *OperatReq) Info() string {
 
 func NewOperatRsp() *OperatRsp {
 	Rsp := new(OperatRsp)
-	Rsp.DealRsp = new(DealRsp)
-	Rsp.HuRsp = new(HuRsp)
-	Rsp.DrawRsp = new(DrawRsp)
-	Rsp.PongRsp = new(PongRsp)
-	Rsp.EatRsp = new(EatRsp)
-	Rsp.GangRsp = new(GangRsp)
-	Rsp.DropRsp = new(DropRsp)
+	Rsp.FillEmpty()
 	return Rsp
 }
 
+// 客户端发来的回复可能缺字段, 补上空的, 后面用的时候不用再判断nil
+func (m *OperatRsp) FillEmpty() {
+	if m.DealRsp == nil {
+		m.DealRsp = new(DealRsp)
+	}
+	if m.HuRsp == nil {
+		m.HuRsp = new(HuRsp)
+	}
+	if m.DrawRsp == nil {
+		m.DrawRsp = new(DrawRsp)
+	}
+	if m.PongRsp == nil {
+		m.PongRsp = new(PongRsp)
+	}
+	if m.EatRsp == nil {
+		m.EatRsp = new(EatRsp)
+	}
+	if m.GangRsp == nil {
+		m.GangRsp = new(GangRsp)
+	}
+	if m.DropRsp == nil {
+		m.DropRsp = new(DropRsp)
+	}
+	if m.DingQueRsp == nil {
+		m.DingQueRsp = new(DingQueRsp)
+	}
+}
+
 func (m *OperatRsp) Info() string {
 	var result []string
 	if m.Type&OperatType_DealOperat != 0 {
@@ -180,6 +211,9 @@ func (m *OperatRsp) Info() string {
 	if m.Type&OperatType_DropOperat != 0 {
 		result = append(result, "出牌:"+m.DropRsp.Info())
 	}
+	if m.Type&OperatType_DingQueOperat != 0 {
+		result = append(result, "定缺:"+m.DingQueRsp.Info())
+	}
 	if len(result) == 0 {
 		return "rsp type err"
 	}
@@ -187,6 +221,9 @@ func (m *OperatRsp) Info() string {
 }
 
 func (m *DealReq) Info() string {
+	if len(m.Flowers) > 0 {
+		return utils.CardsStr(m.Cards) + "花:" + utils.CardsStr(m.Flowers)
+	}
 	return utils.CardsStr(m.Cards)
 }
 
@@ -195,6 +232,9 @@ func (m *DealRsp) Info() string {
 }
 
 func (m *DrawReq) Info() string {
+	if len(m.Flowers) > 0 {
+		return "[" + utils.CardStr(m.Card) + "]花:" + utils.CardsStr(m.Flowers)
+	}
 	return "[" + utils.CardStr(m.Card) + "]"
 }
 
@@ -211,6 +251,9 @@ func (m *HuRsp) Info() string {
 }
 
 func (m *Eat) Info() string {
+	if m == nil {
+		return "nil"
+	}
 	return utils.CardsStr(m.HandCard) + "/" + utils.CardsStr(m.WaveCard)
 }
 
@@ -242,6 +285,9 @@ func (m *PongRsp) Info() string {
 }
 
 func (m *Gang) Info() string {
+	if m == nil {
+		return "[nil]"
+	}
 	return fmt.Sprintf("[%v, %v]", utils.CardsStr(m.Cards), GangTypeStr(m.Type))
 }
 
@@ -268,6 +314,14 @@ func (m *DropRsp) Info() string {
 	return "[" + utils.CardStr(m.DisCard) + "]"
 }
 
+func (m *DingQueReq) Info() string {
+	return "[" + utils.SuitStr(m.Suit) + "]"
+}
+
+func (m *DingQueRsp) Info() string {
+	return "[" + utils.SuitStr(m.Suit) + "]"
+}
+
 func NewOperatMsg() *OperatMsg {
 	msg := new(OperatMsg)
 	msg.Deal = new(DealRsp)
@@ -277,6 +331,7 @@ func NewOperatMsg() *OperatMsg {
 	msg.Eat = new(EatRsp)
 	msg.Gang = new(GangRsp)
 	msg.Drop = new(DropRsp)
+	msg.DingQue = new(DingQueRsp)
 	return msg
 }
 
@@ -304,6 +359,9 @@ func (m *OperatMsg) Info() string {
 	if m.Type&OperatType_DropOperat != 0 {
 		result = append(result, "出牌:"+m.Drop.Info())
 	}
+	if m.Type&OperatType_DingQueOperat != 0 {
+		result = append(result, "定缺:"+m.DingQue.Info())
+	}
 	if len(result) == 0 {
 		return "rsp type err"
 	}
@@ -322,3 +380,22 @@ func (m *Player) GetPlayerIndex(uid uint64) (int, error) {
 	}
 	return 0, errors.New(fmt.Sprintf("uid:%v not in table", uid))
 }
+
+func (m *FlowerMsg) Info() string {
+	return fmt.Sprintf("uid:%v, 补花:%v", m.Uid, utils.CardsStr(m.Flowers))
+}
+
+func (m *WallMsg) Info() string {
+	return fmt.Sprintf("骰子:%v, 第%v面墙第%v墩开门, 共%v张, 头摸了%v张, 尾摸了%v张", m.Dice, m.BreakWall+1, m.BreakPos, m.Total, m.LiveDrawn, m.DeadDrawn)
+}
+
+func (m *DiceMsg) Info() string {
+	if m.Wall == nil {
+		return fmt.Sprintf("dealer:%v", m.Dealer)
+	}
+	return fmt.Sprintf("dealer:%v, %v", m.Dealer, m.Wall.Info())
+}
+
+func (m *PlayerStatusMsg) Info() string {
+	return fmt.Sprintf("uid:%v, %v", m.Uid, PlayerStatusMap[m.Status])
+}
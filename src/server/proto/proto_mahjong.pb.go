@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go.
+// source: proto_mahjong.proto
+// DO NOT EDIT!
+
+package proto
+
+import proto1 "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto1.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type Cmd int32
+
+const (
+	Cmd_CmdUnknown          Cmd = 0
+	Cmd_CmdLoginReq         Cmd = 101
+	Cmd_CmdLoginRsp         Cmd = 102
+	Cmd_CmdCreateTableReq   Cmd = 201
+	Cmd_CmdCreateTableRsp   Cmd = 202
+	Cmd_CmdJoinTableReq     Cmd = 203
+	Cmd_CmdJoinTableRsp     Cmd = 204
+	Cmd_CmdUserJoinTableMsg Cmd = 205
+	Cmd_CmdOperatReq        Cmd = 206
+	Cmd_CmdOperatRsp        Cmd = 207
+	Cmd_CmdOperatMsg        Cmd = 208
+	Cmd_CmdTableOperatReq   Cmd = 209
+	Cmd_CmdTableOperatRsp   Cmd = 210
+	Cmd_CmdTableOperatMsg   Cmd = 211
+	Cmd_CmdFlowerMsg        Cmd = 212
+	Cmd_CmdDiceMsg          Cmd = 213
+	Cmd_CmdHeartbeatReq     Cmd = 214
+	Cmd_CmdHeartbeatRsp     Cmd = 215
+	Cmd_CmdPlayerStatusMsg  Cmd = 216
+	Cmd_CmdTrusteeReq       Cmd = 217
+	Cmd_CmdTrusteeRsp       Cmd = 218
+	Cmd_CmdGetAreaReq       Cmd = 219
+	Cmd_CmdGetAreaRsp       Cmd = 220
+)
+
+var Cmd_name = map[int32]string{
+	0:   "CmdUnknown",
+	101: "CmdLoginReq",
+	102: "CmdLoginRsp",
+	201: "CmdCreateTableReq",
+	202: "CmdCreateTableRsp",
+	203: "CmdJoinTableReq",
+	204: "CmdJoinTableRsp",
+	205: "CmdUserJoinTableMsg",
+	206: "CmdOperatReq",
+	207: "CmdOperatRsp",
+	208: "CmdOperatMsg",
+	209: "CmdTableOperatReq",
+	210: "CmdTableOperatRsp",
+	211: "CmdTableOperatMsg",
+	212: "CmdFlowerMsg",
+	213: "CmdDiceMsg",
+	214: "CmdHeartbeatReq",
+	215: "CmdHeartbeatRsp",
+	216: "CmdPlayerStatusMsg",
+	217: "CmdTrusteeReq",
+	218: "CmdTrusteeRsp",
+	219: "CmdGetAreaReq",
+	220: "CmdGetAreaRsp",
+}
+var Cmd_value = map[string]int32{
+	"CmdUnknown":          0,
+	"CmdLoginReq":         101,
+	"CmdLoginRsp":         102,
+	"CmdCreateTableReq":   201,
+	"CmdCreateTableRsp":   202,
+	"CmdJoinTableReq":     203,
+	"CmdJoinTableRsp":     204,
+	"CmdUserJoinTableMsg": 205,
+	"CmdOperatReq":        206,
+	"CmdOperatRsp":        207,
+	"CmdOperatMsg":        208,
+	"CmdTableOperatReq":   209,
+	"CmdTableOperatRsp":   210,
+	"CmdTableOperatMsg":   211,
+	"CmdFlowerMsg":        212,
+	"CmdDiceMsg":          213,
+	"CmdHeartbeatReq":     214,
+	"CmdHeartbeatRsp":     215,
+	"CmdPlayerStatusMsg":  216,
+	"CmdTrusteeReq":       217,
+	"CmdTrusteeRsp":       218,
+	"CmdGetAreaReq":       219,
+	"CmdGetAreaRsp":       220,
+}
+
+func (x Cmd) String() string {
+	return proto1.EnumName(Cmd_name, int32(x))
+}
+func (Cmd) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
+type MahjongReq struct {
+	Version int32  `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	Uid     uint64 `protobuf:"varint,2,opt,name=uid" json:"uid,omitempty"`
+	Seq     uint32 `protobuf:"varint,3,opt,name=seq" json:"seq,omitempty"`
+	Module  string `protobuf:"bytes,4,opt,name=module" json:"module,omitempty"`
+	Cmd     uint32 `protobuf:"varint,5,opt,name=cmd" json:"cmd,omitempty"`
+	Body    []byte `protobuf:"bytes,6,opt,name=body" json:"body,omitempty"`
+}
+
+func (m *MahjongReq) Reset()                    { *m = MahjongReq{} }
+func (m *MahjongReq) String() string            { return proto1.CompactTextString(m) }
+func (*MahjongReq) ProtoMessage()               {}
+func (*MahjongReq) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
+func (m *MahjongReq) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MahjongReq) GetUid() uint64 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *MahjongReq) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *MahjongReq) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *MahjongReq) GetCmd() uint32 {
+	if m != nil {
+		return m.Cmd
+	}
+	return 0
+}
+
+func (m *MahjongReq) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+type MahjongRsp struct {
+	ErrCode int32  `protobuf:"varint,1,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
+	ErrMsg  string `protobuf:"bytes,2,opt,name=err_msg,json=errMsg" json:"err_msg,omitempty"`
+	Seq     uint32 `protobuf:"varint,3,opt,name=seq" json:"seq,omitempty"`
+	Module  string `protobuf:"bytes,4,opt,name=module" json:"module,omitempty"`
+	Cmd     uint32 `protobuf:"varint,5,opt,name=cmd" json:"cmd,omitempty"`
+	Body    []byte `protobuf:"bytes,6,opt,name=body" json:"body,omitempty"`
+}
+
+func (m *MahjongRsp) Reset()                    { *m = MahjongRsp{} }
+func (m *MahjongRsp) String() string            { return proto1.CompactTextString(m) }
+func (*MahjongRsp) ProtoMessage()               {}
+func (*MahjongRsp) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+func (m *MahjongRsp) GetErrCode() int32 {
+	if m != nil {
+		return m.ErrCode
+	}
+	return 0
+}
+
+func (m *MahjongRsp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *MahjongRsp) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *MahjongRsp) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *MahjongRsp) GetCmd() uint32 {
+	if m != nil {
+		return m.Cmd
+	}
+	return 0
+}
+
+func (m *MahjongRsp) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func init() {
+	proto1.RegisterType((*MahjongReq)(nil), "proto.mahjongReq")
+	proto1.RegisterType((*MahjongRsp)(nil), "proto.mahjongRsp")
+	proto1.RegisterEnum("proto.Cmd", Cmd_name, Cmd_value)
+}
+
+func init() { proto1.RegisterFile("proto_mahjong.proto", fileDescriptor1) }
+
+var fileDescriptor1 = []byte{
+	// 433 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcb, 0x6e, 0xd4, 0x30,
+	0x14, 0x86, 0x31, 0x73, 0x81, 0x1e, 0x5a, 0xc6, 0x3d, 0x45, 0x6d, 0xd8, 0x45, 0x5d, 0x45, 0x2c,
+	0xd8, 0xf0, 0x04, 0xc8, 0x08, 0x10, 0xa2, 0x02, 0x05, 0x58, 0x8f, 0x32, 0xe3, 0x43, 0x08, 0x8c,
+	0x63, 0x8f, 0x4f, 0x42, 0xd5, 0x37, 0x60, 0xc9, 0x6b, 0x71, 0xbf, 0xdf, 0xd9, 0xf3, 0x1a, 0xc8,
+	0x6e, 0x53, 0xe8, 0x74, 0x96, 0x5d, 0xe5, 0x3f, 0x9f, 0x7f, 0xff, 0xf9, 0xe3, 0xc8, 0xb0, 0xe1,
+	0xbc, 0x6d, 0xec, 0xd8, 0x14, 0x8f, 0x1e, 0xdb, 0xba, 0xbc, 0x1c, 0x27, 0x1c, 0xc4, 0xc7, 0xf6,
+	0x33, 0x01, 0x70, 0xb0, 0x90, 0xd3, 0x1c, 0x13, 0x38, 0xf3, 0x94, 0x3c, 0x57, 0xb6, 0x4e, 0x44,
+	0x2a, 0xb2, 0x41, 0xde, 0x8d, 0x28, 0xa1, 0xd7, 0x56, 0x3a, 0x39, 0x9d, 0x8a, 0xac, 0x9f, 0x07,
+	0x19, 0x08, 0xd3, 0x3c, 0xe9, 0xa5, 0x22, 0x5b, 0xcb, 0x83, 0xc4, 0x4d, 0x18, 0x1a, 0xab, 0xdb,
+	0x19, 0x25, 0xfd, 0x54, 0x64, 0x2b, 0xf9, 0xc1, 0x14, 0x9c, 0x53, 0xa3, 0x93, 0xc1, 0xbe, 0x73,
+	0x6a, 0x34, 0x22, 0xf4, 0x27, 0x56, 0xef, 0x25, 0xc3, 0x54, 0x64, 0xab, 0x79, 0xd4, 0xdb, 0xcf,
+	0xff, 0xab, 0xc2, 0x0e, 0x2f, 0xc2, 0x59, 0xf2, 0x7e, 0x3c, 0xb5, 0x9a, 0xba, 0x2e, 0xe4, 0xbd,
+	0xb2, 0x9a, 0x70, 0x0b, 0x82, 0x1c, 0x1b, 0x2e, 0x63, 0x9f, 0x95, 0x7c, 0x48, 0xde, 0xef, 0x70,
+	0x79, 0xd2, 0x95, 0x2e, 0xfd, 0xe9, 0x41, 0x4f, 0x19, 0x8d, 0xe7, 0x01, 0x94, 0xd1, 0x0f, 0xea,
+	0x27, 0xb5, 0xdd, 0xad, 0xe5, 0x29, 0x1c, 0xc1, 0x39, 0x65, 0xf4, 0x6d, 0x5b, 0x56, 0x75, 0x4e,
+	0x73, 0x49, 0x47, 0x00, 0x3b, 0xf9, 0x10, 0x37, 0x61, 0x5d, 0x19, 0xad, 0x3c, 0x15, 0x0d, 0xdd,
+	0x2f, 0x26, 0x33, 0x0a, 0xbe, 0x17, 0x62, 0x09, 0x67, 0x27, 0x5f, 0x0a, 0xbc, 0x00, 0x23, 0x65,
+	0xf4, 0x2d, 0x5b, 0xd5, 0x87, 0xee, 0x57, 0xc7, 0x29, 0x3b, 0xf9, 0x5a, 0x60, 0x02, 0x1b, 0xa1,
+	0x0d, 0x93, 0x3f, 0x5c, 0xd9, 0xe1, 0x52, 0xbe, 0x11, 0xb8, 0x0e, 0xab, 0xca, 0xe8, 0x3b, 0x8e,
+	0x7c, 0xd1, 0x84, 0x88, 0xb7, 0x0b, 0x88, 0x9d, 0x7c, 0x77, 0x14, 0x85, 0x8d, 0xef, 0xbb, 0x5a,
+	0x31, 0xea, 0xdf, 0xee, 0x0f, 0xcb, 0x38, 0x3b, 0xf9, 0x71, 0x09, 0x0f, 0x39, 0x9f, 0xba, 0xe8,
+	0xeb, 0x33, 0xbb, 0x4b, 0xe1, 0x87, 0xc8, 0xcf, 0x02, 0x47, 0xf1, 0xec, 0xae, 0x55, 0xd3, 0x58,
+	0xf2, 0x4b, 0xf7, 0x51, 0x37, 0xa9, 0xf0, 0xcd, 0x84, 0xf6, 0xdf, 0xf4, 0xf5, 0x38, 0x65, 0x27,
+	0xbf, 0x09, 0xdc, 0x02, 0x54, 0x46, 0xdf, 0x9d, 0x15, 0x7b, 0xe4, 0xef, 0x35, 0x45, 0xd3, 0x72,
+	0x08, 0xf9, 0x2e, 0x10, 0x61, 0x2d, 0x14, 0xf0, 0x2d, 0x37, 0x14, 0x4f, 0xeb, 0xc7, 0x22, 0x63,
+	0x27, 0x7f, 0x76, 0xec, 0x06, 0x35, 0x57, 0x3d, 0x15, 0xc1, 0xf7, 0x6b, 0x91, 0xb1, 0x93, 0xbf,
+	0xc5, 0x64, 0x18, 0xaf, 0xc3, 0x95, 0xbf, 0x03, 0x00, 0xe4, 0x1d, 0x14, 0x61, 0x2c, 0x03, 0x00,
+	0x00,
+}
@@ -0,0 +1,481 @@
+package sdk
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jxbdlut/leaf/log"
+	"server/conf"
+	"server/proto"
+)
+
+// 客户端sdk: 连服务器, 登录建桌加桌, 按seq对上请求和回复.
+// 服务器推过来的消息先更新本地的桌面(Table), 轮到自己的时候交给Decider回复, 再放进事件channel.
+// 机器人, 压测, 终端客户端和集成测试都用它
+
+const (
+	HeartbeatInterval = 10 * time.Second
+	DefaultTimeout    = 2 * time.Second
+)
+
+var (
+	ErrTimeout = errors.New("time out")
+	ErrClosed  = errors.New("connection closed")
+)
+
+// 回复带了错误码
+type RspError struct {
+	Msg     string // 请求的名字
+	ErrCode int32
+	ErrMsg  string
+}
+
+func (e *RspError) Error() string {
+	return fmt.Sprintf("%v err_code:%v, %v", e.Msg, e.ErrCode, e.ErrMsg)
+}
+
+// 服务器推过来的消息, 回复OperatReq和TableOperatReq要带上Seq
+type Event struct {
+	Msg interface{}
+	Seq uint32
+}
+
+// 压测用来统计, 方法在读消息和发请求的goroutine里调用, 要自己加锁
+type Observer interface {
+	Observe(name string, d time.Duration, err error) // 一次请求的往返时间, 超时或者回复带错误码的时候err不为空
+	Receive(name string)                             // 收到一条消息
+	Error(reason string)                             // 不属于某个请求的错误
+}
+
+type Options struct {
+	Name      string
+	Timeout   time.Duration // 请求等回复的时间, 0用DefaultTimeout
+	Heartbeat time.Duration // 登录之后多久发一次心跳, 0不发
+	Events    int           // 事件channel的缓冲, 0表示不要事件; 要了就得一直读, 读不及时会卡住读消息
+	// 轮到自己的时候怎么回, nil的时候OperatReq和TableOperatReq交给读事件的人用Reply回复.
+	// 发牌摸牌只是确认, 不管有没有Decider都自动回
+	Decider  Decider
+	Delay    func() time.Duration // Decider做完决定之后等多久再回, 模拟人想的时间, nil马上回
+	Observer Observer
+}
+
+type Client struct {
+	Uid   uint64
+	Name  string
+	Table *Table // 读消息的goroutine改, 别的goroutine读之前要先Lock
+
+	opts        Options
+	conn        net.Conn
+	processor   *proto.EnvelopeProcessor
+	seq         uint32
+	mutex       sync.Mutex
+	pending     map[uint32]chan interface{}
+	write_mutex sync.Mutex
+	events      chan Event
+	done        chan struct{}
+	beat_once   sync.Once
+	rtt         int64
+}
+
+func Dial(addr string, uid uint64, opts Options) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, 3*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn, uid, opts), nil
+}
+
+// 用已经连好的连接, 测试的时候可以传net.Pipe
+func NewClient(conn net.Conn, uid uint64, opts Options) *Client {
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	c := &Client{Uid: uid, Name: opts.Name, opts: opts, conn: conn}
+	c.Table = NewTable(uid, opts.Name)
+	c.processor = proto.NewEnvelopeProcessor(proto.CodecProtobuf, false)
+	c.processor.Uid = uid
+	c.pending = make(map[uint32]chan interface{})
+	c.done = make(chan struct{})
+	if opts.Events > 0 {
+		c.events = make(chan Event, opts.Events)
+	}
+	go c.run()
+	return c
+}
+
+// 连接断开之后关闭, 没要事件的时候是nil
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// 连接断开之后关闭
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// 最近一次心跳的往返时间
+func (c *Client) RTT() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.rtt))
+}
+
+func (c *Client) Close() {
+	c.conn.Close()
+}
+
+func (c *Client) Login() (bool, error) {
+	sum := md5.Sum([]byte(strconv.FormatUint(c.Uid, 10)))
+	msg, err := c.SendRcv(&proto.LoginReq{
+		Uid:     c.Uid,
+		Name:    c.Name,
+		Passwd:  hex.EncodeToString(sum[:]),
+		Version: proto.CurVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+	rsp := msg.(*proto.LoginRsp)
+	c.write_mutex.Lock()
+	c.processor.Version = rsp.Version
+	c.write_mutex.Unlock()
+	if c.opts.Heartbeat > 0 {
+		c.beat_once.Do(func() { go c.heartbeat() })
+	}
+	return rsp.NeedRecover, nil
+}
+
+// 建桌的人服务器直接放到第一个座位
+func (c *Client) CreateTable(req *proto.CreateTableReq) (uint32, error) {
+	msg, err := c.SendRcv(req)
+	if err != nil {
+		return 0, err
+	}
+	tid := msg.(*proto.CreateTableRsp).TableId
+	c.Table.Lock()
+	c.Table.Reset(tid)
+	c.Table.Me().Pos = 1
+	c.Table.Unlock()
+	return tid, nil
+}
+
+func (c *Client) JoinTable(tid uint32) (int32, error) {
+	msg, err := c.SendRcv(&proto.JoinTableReq{TableId: tid})
+	if err != nil {
+		return 0, err
+	}
+	// 没登录的时候服务器回的是CreateTableRsp
+	rsp, ok := msg.(*proto.JoinTableRsp)
+	if !ok {
+		return 0, fmt.Errorf("JoinTableReq got %v", reflect.TypeOf(msg))
+	}
+	c.Table.Lock()
+	c.Table.Reset(tid)
+	c.Table.Me().Pos = int(rsp.Pos)
+	c.Table.Unlock()
+	return rsp.Pos, nil
+}
+
+func (c *Client) GetAreas() (*proto.GetAreaRsp, error) {
+	msg, err := c.SendRcv(&proto.GetAreaReq{})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*proto.GetAreaRsp), nil
+}
+
+func (c *Client) Trustee(on bool) error {
+	if _, err := c.SendRcv(&proto.TrusteeReq{On: on}); err != nil {
+		return err
+	}
+	// 服务器只通知同桌, 自己的状态在这里改, 取消托管之后才会接着回复
+	status := proto.PlayerStatus_StatusOnline
+	if on {
+		status = proto.PlayerStatus_StatusTrustee
+	}
+	c.Table.Lock()
+	c.Table.Me().Status = status
+	c.Table.Unlock()
+	return nil
+}
+
+// 服务器带回发送时间, 用来算rtt
+func (c *Client) Heartbeat() (time.Duration, error) {
+	msg, err := c.SendRcv(&proto.HeartbeatReq{ClientTime: time.Now().UnixNano() / int64(time.Millisecond)})
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(time.Unix(0, msg.(*proto.HeartbeatRsp).ClientTime*int64(time.Millisecond)))
+	atomic.StoreInt64(&c.rtt, int64(rtt))
+	return rtt, nil
+}
+
+func (c *Client) heartbeat() {
+	ticker := time.NewTicker(c.opts.Heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			rtt, err := c.Heartbeat()
+			if err != nil {
+				log.Error("uid:%v, heartbeat err:%v", c.Uid, err)
+				continue
+			}
+			log.Debug("uid:%v, rtt:%v", c.Uid, rtt)
+		}
+	}
+}
+
+// 发请求等回复. 回复带错误码的时候回复和*RspError都返回
+func (c *Client) SendRcv(msg interface{}) (interface{}, error) {
+	name := msgName(msg)
+	// 有缓冲, 超时之后才到的回复不会卡住读消息
+	ch := make(chan interface{}, 1)
+	// 心跳和打牌在不同的goroutine里发, 客户端发的seq都是单数
+	seq := atomic.AddUint32(&c.seq, 2) - 1
+	c.mutex.Lock()
+	c.pending[seq] = ch
+	c.mutex.Unlock()
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, seq)
+		c.mutex.Unlock()
+	}()
+
+	start := time.Now()
+	if err := c.write(msg, seq); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(c.opts.Timeout)
+	defer timer.Stop()
+	select {
+	case rsp := <-ch:
+		var err error
+		if r, ok := rsp.(interface {
+			GetErrCode() int32
+			GetErrMsg() string
+		}); ok && r.GetErrCode() != 0 {
+			err = &RspError{Msg: name, ErrCode: r.GetErrCode(), ErrMsg: r.GetErrMsg()}
+		}
+		c.observe(name, time.Since(start), err)
+		return rsp, err
+	case <-timer.C:
+		c.observe(name, c.opts.Timeout, ErrTimeout)
+		log.Error("uid:%v, %v seq:%v time out", c.Uid, name, seq)
+		return nil, ErrTimeout
+	case <-c.done:
+		return nil, ErrClosed
+	}
+}
+
+// 回复服务器的OperatReq和TableOperatReq
+func (c *Client) Reply(msg interface{}, seq uint32) error {
+	if rsp, ok := msg.(*proto.OperatRsp); ok && rsp.Type == proto.OperatType_DingQueOperat && rsp.DingQueRsp != nil {
+		c.Table.Lock()
+		c.Table.Me().Que = rsp.DingQueRsp.Suit
+		c.Table.Unlock()
+	}
+	return c.write(msg, seq)
+}
+
+func (c *Client) write(msg interface{}, seq uint32) error {
+	c.write_mutex.Lock()
+	defer c.write_mutex.Unlock()
+	data, err := c.processor.Marshal(msg, seq)
+	if err != nil {
+		log.Error("uid:%v, marshal message %v error: %v", c.Uid, reflect.TypeOf(msg), err)
+		c.error("marshal")
+		return err
+	}
+	if err = writeFrame(c.conn, data...); err != nil {
+		log.Error("uid:%v, write message %v error: %v", c.Uid, reflect.TypeOf(msg), err)
+		c.error("write")
+		return err
+	}
+	return nil
+}
+
+func (c *Client) run() {
+	defer func() {
+		c.conn.Close()
+		close(c.done)
+		if c.events != nil {
+			close(c.events)
+		}
+		log.Debug("uid:%v, client exit", c.Uid)
+	}()
+	for {
+		data, err := readFrame(c.conn)
+		if err != nil {
+			if err != io.EOF {
+				log.Debug("uid:%v, read message: %v", c.Uid, err)
+			}
+			return
+		}
+		msg, seq, err := c.processor.Unmarshal(data)
+		if err != nil {
+			log.Error("uid:%v, unmarshal message: %v", c.Uid, err)
+			c.error("unmarshal")
+			continue
+		}
+		if c.opts.Observer != nil {
+			c.opts.Observer.Receive(msgName(msg))
+		}
+		c.mutex.Lock()
+		ch, ok := c.pending[seq]
+		delete(c.pending, seq)
+		c.mutex.Unlock()
+		if ok {
+			ch <- msg
+			continue
+		}
+		c.handle(msg, seq)
+	}
+}
+
+// 推过来的消息: 先改桌面, 该回复的回复, 再交给读事件的人
+func (c *Client) handle(msg interface{}, seq uint32) {
+	c.Table.Lock()
+	c.Table.Apply(msg)
+	rsp, think := c.decide(msg)
+	c.Table.Unlock()
+	log.Debug("uid:%v, seq:%v, %v", c.Uid, seq, msgInfo(msg))
+	if rsp != nil {
+		if think && c.opts.Delay != nil {
+			// 等的时候不能卡住读消息
+			time.AfterFunc(c.opts.Delay(), func() { c.Reply(rsp, seq) })
+		} else {
+			c.Reply(rsp, seq)
+		}
+	}
+	if c.events != nil {
+		c.events <- Event{Msg: msg, Seq: seq}
+	}
+}
+
+// 托管的时候服务器不等回复, 什么都不回. think表示是Decider想出来的, 不是确认
+func (c *Client) decide(msg interface{}) (interface{}, bool) {
+	if c.Table.Trustee() {
+		return nil, false
+	}
+	switch req := msg.(type) {
+	case *proto.OperatReq:
+		if req.Type&proto.OperatType_DealOperat != 0 {
+			rsp := proto.NewOperatRsp()
+			rsp.Type = proto.OperatType_DealOperat
+			return rsp, false
+		}
+		if req.Type&proto.OperatType_DrawOperat != 0 {
+			rsp := proto.NewOperatRsp()
+			rsp.Type = proto.OperatType_DrawOperat
+			return rsp, false
+		}
+		if c.opts.Decider == nil {
+			return nil, false
+		}
+		rsp := c.opts.Decider.Operat(c.Table, req)
+		if rsp == nil {
+			rsp = Pass(req)
+		}
+		log.Debug("uid:%v, %v, %v", c.Uid, req.Info(), rsp.Info())
+		return rsp, true
+	case *proto.TableOperatReq:
+		if c.opts.Decider == nil {
+			return nil, false
+		}
+		return &proto.TableOperatRsp{Type: req.Type, Ok: c.opts.Decider.TableOperat(c.Table, req)}, true
+	}
+	return nil, false
+}
+
+func (c *Client) observe(name string, d time.Duration, err error) {
+	if c.opts.Observer != nil {
+		c.opts.Observer.Observe(name, d, err)
+	}
+}
+
+func (c *Client) error(reason string) {
+	if c.opts.Observer != nil {
+		c.opts.Observer.Error(reason)
+	}
+}
+
+func msgName(msg interface{}) string {
+	t := reflect.TypeOf(msg)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func msgInfo(msg interface{}) string {
+	if m, ok := msg.(interface {
+		Info() string
+	}); ok {
+		return msgName(msg) + " " + m.Info()
+	}
+	return fmt.Sprintf("%v %v", msgName(msg), msg)
+}
+
+// 和gate一样的分包: 前面conf.LenMsgLen个字节是长度
+func byteOrder() binary.ByteOrder {
+	if conf.LittleEndian {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	head := make([]byte, conf.LenMsgLen)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return nil, err
+	}
+	var n uint32
+	switch conf.LenMsgLen {
+	case 1:
+		n = uint32(head[0])
+	case 2:
+		n = uint32(byteOrder().Uint16(head))
+	default:
+		n = byteOrder().Uint32(head)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func writeFrame(w io.Writer, args ...[]byte) error {
+	var n int
+	for _, arg := range args {
+		n += len(arg)
+	}
+	if uint64(n) > uint64(1)<<(8*uint(conf.LenMsgLen))-1 {
+		return fmt.Errorf("message too long: %v", n)
+	}
+	buf := make([]byte, conf.LenMsgLen, conf.LenMsgLen+n)
+	switch conf.LenMsgLen {
+	case 1:
+		buf[0] = byte(n)
+	case 2:
+		byteOrder().PutUint16(buf, uint16(n))
+	default:
+		byteOrder().PutUint32(buf, uint32(n))
+	}
+	for _, arg := range args {
+		buf = append(buf, arg...)
+	}
+	_, err := w.Write(buf)
+	return err
+}
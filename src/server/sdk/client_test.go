@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"server/proto"
+	"server/utils"
+)
+
+// 用net.Pipe的另一头假装服务器
+type fakeServer struct {
+	t         *testing.T
+	conn      net.Conn
+	processor *proto.EnvelopeProcessor
+}
+
+func newFakeServer(t *testing.T, opts Options) (*fakeServer, *Client) {
+	server_conn, client_conn := net.Pipe()
+	s := &fakeServer{t: t, conn: server_conn, processor: proto.NewEnvelopeProcessor(proto.CodecProtobuf, true)}
+	return s, NewClient(client_conn, 1001, opts)
+}
+
+func (s *fakeServer) read() (interface{}, uint32) {
+	s.conn.SetReadDeadline(time.Now().Add(time.Second))
+	data, err := readFrame(s.conn)
+	if err != nil {
+		s.t.Fatalf("server read: %v", err)
+	}
+	msg, seq, err := s.processor.Unmarshal(data)
+	if err != nil {
+		s.t.Fatalf("server unmarshal: %v", err)
+	}
+	return msg, seq
+}
+
+func (s *fakeServer) send(msg interface{}, seq uint32) {
+	data, err := s.processor.Marshal(msg, seq)
+	if err != nil {
+		s.t.Fatalf("server marshal: %v", err)
+	}
+	if err := writeFrame(s.conn, data...); err != nil {
+		s.t.Fatalf("server write: %v", err)
+	}
+}
+
+func (s *fakeServer) readOperatRsp(seq uint32) *proto.OperatRsp {
+	msg, rsp_seq := s.read()
+	rsp, ok := msg.(*proto.OperatRsp)
+	if !ok || rsp_seq != seq {
+		s.t.Fatalf("got %T seq:%v, want OperatRsp seq:%v", msg, rsp_seq, seq)
+	}
+	return rsp
+}
+
+func waitEvent(t *testing.T, c *Client) interface{} {
+	select {
+	case ev := <-c.Events():
+		return ev.Msg
+	case <-time.After(time.Second):
+		t.Fatalf("no event")
+	}
+	return nil
+}
+
+func TestClientRequest(t *testing.T) {
+	s, c := newFakeServer(t, Options{Name: "a", Timeout: 100 * time.Millisecond})
+	defer c.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.Login()
+		errs <- err
+	}()
+	msg, seq := s.read()
+	if req, ok := msg.(*proto.LoginReq); !ok || req.Uid != 1001 || req.Name != "a" || seq%2 != 1 {
+		t.Fatalf("login req %v seq:%v", msg, seq)
+	}
+	s.send(&proto.LoginRsp{Version: proto.CurVersion}, seq)
+	if err := <-errs; err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	go func() {
+		_, err := c.CreateTable(&proto.CreateTableReq{})
+		errs <- err
+	}()
+	_, seq = s.read()
+	s.send(&proto.CreateTableRsp{ErrCode: 1, ErrMsg: "no area"}, seq)
+	if err, ok := (<-errs).(*RspError); !ok || err.ErrCode != 1 {
+		t.Errorf("CreateTable err: %v", err)
+	}
+
+	// 服务器不回
+	go func() {
+		_, err := c.GetAreas()
+		errs <- err
+	}()
+	s.read()
+	if err := <-errs; err != ErrTimeout {
+		t.Errorf("GetAreas err: %v, want time out", err)
+	}
+
+	s.conn.Close()
+	<-c.Done()
+	if _, err := c.Heartbeat(); err == nil {
+		t.Errorf("Heartbeat after close: no error")
+	}
+}
+
+func TestClientDecide(t *testing.T) {
+	s, c := newFakeServer(t, Options{Events: 16, Decider: Bot{}})
+	defer c.Close()
+
+	cards, _ := utils.ParseCards("123m456p789s112z7z")
+	deal := proto.NewOperatReq()
+	deal.Type = proto.OperatType_DealOperat
+	deal.DealReq.Cards = cards
+	deal.DealReq.Dealer = 1001
+	s.send(deal, 2)
+	if rsp := s.readOperatRsp(2); rsp.Type != proto.OperatType_DealOperat {
+		t.Errorf("deal rsp %v", rsp.Info())
+	}
+	waitEvent(t, c)
+
+	draw := proto.NewOperatReq()
+	draw.Type = proto.OperatType_DrawOperat
+	draw.DrawReq.Card = 406
+	s.send(draw, 4)
+	s.readOperatRsp(4)
+	waitEvent(t, c)
+
+	drop := proto.NewOperatReq()
+	drop.Type = proto.OperatType_DropOperat
+	s.send(drop, 6)
+	rsp := s.readOperatRsp(6)
+	if rsp.Type != proto.OperatType_DropOperat || rsp.DropRsp.DisCard != 402 {
+		t.Errorf("drop rsp %v, want 2z", rsp.Info())
+	}
+	waitEvent(t, c)
+
+	msg := proto.NewOperatMsg()
+	msg.Uid, msg.Type = 1001, proto.OperatType_DropOperat
+	msg.Drop.DisCard = rsp.DropRsp.DisCard
+	s.send(msg, 8)
+	waitEvent(t, c)
+	c.Table.Lock()
+	if len(c.Table.Cards) != 13 || utils.Index(c.Table.Cards, rsp.DropRsp.DisCard) != -1 || c.Table.DrawCard != 0 {
+		t.Errorf("hand after drop: %v", utils.CardsNotation(c.Table.Cards))
+	}
+	c.Table.Unlock()
+
+	// 托管之后不回复, 下一条收到的是心跳
+	s.send(&proto.PlayerStatusMsg{Uid: 1001, Status: proto.PlayerStatus_StatusTrustee}, 10)
+	waitEvent(t, c)
+	s.send(drop, 12)
+	waitEvent(t, c)
+	go c.Heartbeat()
+	if msg, _ := s.read(); msg == nil {
+		t.Fatalf("no message")
+	} else if _, ok := msg.(*proto.HeartbeatReq); !ok {
+		t.Errorf("got %T while trustee, want HeartbeatReq", msg)
+	}
+
+	s.conn.Close()
+	for range c.Events() {
+	}
+}
@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"server/proto"
+	"server/utils"
+)
+
+// 轮到自己的时候怎么回. Client在读消息的goroutine里调用, 调用的时候桌面已经按这条请求更新过并且锁上了.
+// Operat返回nil当作过
+type Decider interface {
+	Operat(t *Table, req *proto.OperatReq) *proto.OperatRsp
+	TableOperat(t *Table, req *proto.TableOperatReq) bool
+}
+
+// 只管打牌的Decider, 开始和继续都同意
+type DeciderFunc func(t *Table, req *proto.OperatReq) *proto.OperatRsp
+
+func (f DeciderFunc) Operat(t *Table, req *proto.OperatReq) *proto.OperatRsp {
+	return f(t, req)
+}
+
+func (f DeciderFunc) TableOperat(t *Table, req *proto.TableOperatReq) bool {
+	return true
+}
+
+// 过: 回最先问的那种操作, 带ok=false. 只要出牌的时候没有过, 不填牌让服务器打默认的那张
+func Pass(req *proto.OperatReq) *proto.OperatRsp {
+	rsp := proto.NewOperatRsp()
+	switch {
+	case req.Type&proto.OperatType_HuOperat != 0:
+		rsp.Type = proto.OperatType_HuOperat
+		rsp.HuRsp = &proto.HuRsp{Card: req.GetHuReq().GetCard(), Type: req.GetHuReq().GetType(), Lose: req.GetHuReq().GetLose()}
+	case req.Type&proto.OperatType_GangOperat != 0:
+		rsp.Type = proto.OperatType_GangOperat
+	case req.Type&proto.OperatType_PongOperat != 0:
+		rsp.Type = proto.OperatType_PongOperat
+	case req.Type&proto.OperatType_EatOperat != 0:
+		rsp.Type = proto.OperatType_EatOperat
+	case req.Type&proto.OperatType_DingQueOperat != 0:
+		rsp.Type = proto.OperatType_DingQueOperat
+		rsp.DingQueRsp.Suit = req.GetDingQueReq().GetSuit()
+	case req.Type&proto.OperatType_DropOperat != 0:
+		rsp.Type = proto.OperatType_DropOperat
+	}
+	return rsp
+}
+
+// 原来客户端机器人的打法: 只胡自摸, 能杠就杠, 能碰就碰, 能吃就吃, 定缺听服务器的,
+// 出牌先打缺门, 再打单张, 都没有随便打一张
+type Bot struct{}
+
+func (Bot) TableOperat(t *Table, req *proto.TableOperatReq) bool {
+	return true
+}
+
+func (Bot) Operat(t *Table, req *proto.OperatReq) *proto.OperatRsp {
+	rsp := proto.NewOperatRsp()
+	switch {
+	case req.Type&proto.OperatType_HuOperat != 0:
+		hu := req.HuReq
+		rsp.Type = proto.OperatType_HuOperat
+		rsp.HuRsp = &proto.HuRsp{Ok: hu.Type != proto.HuType_Nomal, Card: hu.Card, Type: hu.Type, Lose: hu.Lose}
+	case req.Type&proto.OperatType_GangOperat != 0:
+		rsp.Type = proto.OperatType_GangOperat
+		rsp.GangRsp = &proto.GangRsp{Ok: true, Gang: req.GangReq.Gang[0]}
+	case req.Type&proto.OperatType_PongOperat != 0:
+		rsp.Type = proto.OperatType_PongOperat
+		rsp.PongRsp = &proto.PongRsp{Ok: true, Card: req.PongReq.Card}
+	case req.Type&proto.OperatType_EatOperat != 0:
+		rsp.Type = proto.OperatType_EatOperat
+		rsp.EatRsp = &proto.EatRsp{Ok: true, Eat: req.EatReq.Eat[0]}
+	case req.Type&proto.OperatType_DropOperat != 0:
+		rsp.Type = proto.OperatType_DropOperat
+		rsp.DropRsp.DisCard = BotDrop(t)
+	case req.Type&proto.OperatType_DingQueOperat != 0:
+		rsp.Type = proto.OperatType_DingQueOperat
+		rsp.DingQueRsp.Suit = req.DingQueReq.Suit
+	default:
+		return nil
+	}
+	return rsp
+}
+
+func BotDrop(t *Table) int32 {
+	separate_result := utils.SeparateCards(t.Cards, t.HunCard)
+	discard := utils.DropSingle(separate_result)
+	if que := t.Que(); que != 0 && len(separate_result[que]) > 0 {
+		discard = separate_result[que][0]
+	}
+	if discard == 0 {
+		discard = utils.DropRand(t.Cards, t.HunCard)
+	}
+	return discard
+}
@@ -0,0 +1,221 @@
+package sdk
+
+import (
+	"sort"
+	"sync"
+
+	"server/proto"
+	"server/utils"
+)
+
+type Seat struct {
+	Uid     uint64
+	Name    string
+	Pos     int
+	Wind    int32
+	Que     int32
+	Waves   []*proto.Wave
+	Drops   []int32
+	Flowers []int32
+	Status  proto.PlayerStatus
+	Win     bool
+}
+
+// 本地镜像的桌面, 只按服务器确认过的消息改: 发牌摸牌看OperatReq, 打吃碰杠看广播回来的OperatMsg.
+// 自己的手牌是全的, 别人的只有牌河, 吃碰杠和花
+type Table struct {
+	sync.Mutex
+	Tid        uint32
+	Uid        uint64 // 自己
+	Name       string
+	Seats      map[uint64]*Seat
+	Dealer     uint64
+	RoundWind  int32
+	LianZhuang int32
+	Hands      int     // 发过几次牌
+	Cards      []int32 // 自己的手牌, 摸的那张也在里面
+	DrawCard   int32   // 刚摸的牌, 打出去之后清零
+	FanCard    int32
+	HunCard    int32
+	LastDrop   uint64 // 最后打牌的人, 被吃碰杠的时候从他的牌河里拿走那张牌
+}
+
+func NewTable(uid uint64, name string) *Table {
+	t := &Table{Uid: uid, Name: name}
+	t.Reset(0)
+	return t
+}
+
+// 进新桌子的时候清空
+func (t *Table) Reset(tid uint32) {
+	t.Tid = tid
+	t.Seats = make(map[uint64]*Seat)
+	t.Dealer, t.RoundWind, t.LianZhuang, t.Hands = 0, 0, 0, 0
+	t.Cards, t.DrawCard, t.FanCard, t.HunCard, t.LastDrop = nil, 0, 0, 0, 0
+	t.Seat(t.Uid).Name = t.Name
+}
+
+// 机器人桌不会推入座消息, 第一次看到的uid现加
+func (t *Table) Seat(uid uint64) *Seat {
+	seat, ok := t.Seats[uid]
+	if !ok {
+		seat = &Seat{Uid: uid, Pos: len(t.Seats) + 1}
+		t.Seats[uid] = seat
+	}
+	return seat
+}
+
+func (t *Table) Me() *Seat {
+	return t.Seat(t.Uid)
+}
+
+func (t *Table) Que() int32 {
+	return t.Me().Que
+}
+
+func (t *Table) Trustee() bool {
+	return t.Me().Status == proto.PlayerStatus_StatusTrustee
+}
+
+// 按座位排好
+func (t *Table) SortedSeats() []*Seat {
+	var seats []*Seat
+	for _, seat := range t.Seats {
+		seats = append(seats, seat)
+	}
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Pos < seats[j].Pos })
+	return seats
+}
+
+// 排好序的手牌, 混在前面
+func (t *Table) Hand() []int32 {
+	cards := utils.Copy(t.Cards)
+	utils.SortCards(cards, t.HunCard)
+	return cards
+}
+
+// 服务器推过来的消息, 调用的人要先Lock
+func (t *Table) Apply(msg interface{}) {
+	switch m := msg.(type) {
+	case *proto.UserJoinTableMsg:
+		if m.Tid != 0 {
+			t.Tid = m.Tid
+		}
+		t.Dealer, t.RoundWind = m.Dealer, m.RoundWind
+		for _, s := range m.Seats {
+			seat := t.Seat(s.Uid)
+			seat.Name, seat.Pos, seat.Wind = s.Name, int(s.Pos), s.Wind
+		}
+	case *proto.DiceMsg:
+		t.Dealer = m.Dealer
+	case *proto.FlowerMsg:
+		seat := t.Seat(m.Uid)
+		seat.Flowers = append(seat.Flowers, m.Flowers...)
+	case *proto.PlayerStatusMsg:
+		t.Seat(m.Uid).Status = m.Status
+	case *proto.OperatReq:
+		t.applyReq(m)
+	case *proto.OperatMsg:
+		t.applyMsg(m)
+	}
+}
+
+func (t *Table) applyReq(req *proto.OperatReq) {
+	me := t.Me()
+	switch {
+	case req.Type&proto.OperatType_DealOperat != 0:
+		deal := req.GetDealReq()
+		for _, seat := range t.Seats {
+			seat.Waves, seat.Drops, seat.Flowers, seat.Que, seat.Win = nil, nil, nil, 0, false
+		}
+		t.Dealer, t.RoundWind, t.LianZhuang = deal.GetDealer(), deal.GetRoundWind(), deal.GetLianZhuang()
+		t.Cards = utils.Copy(deal.GetCards())
+		t.FanCard, t.HunCard, t.DrawCard, t.LastDrop = deal.GetFanCard(), deal.GetHunCard(), 0, 0
+		t.Hands++
+		me.Wind = deal.GetSeatWind()
+		me.Flowers = utils.Copy(deal.GetFlowers())
+	case req.Type&proto.OperatType_DrawOperat != 0:
+		draw := req.GetDrawReq()
+		t.Cards = append(t.Cards, draw.GetCard())
+		t.DrawCard = draw.GetCard()
+		me.Flowers = append(me.Flowers, draw.GetFlowers()...)
+	}
+}
+
+func (t *Table) applyMsg(m *proto.OperatMsg) {
+	seat := t.Seat(m.Uid)
+	me := m.Uid == t.Uid
+	// 被吃碰明杠的那张从打牌的人的牌河里拿走
+	claim := func(card int32) {
+		if from, ok := t.Seats[t.LastDrop]; ok && len(from.Drops) > 0 && from.Drops[len(from.Drops)-1] == card {
+			from.Drops = from.Drops[:len(from.Drops)-1]
+		}
+	}
+	switch {
+	case m.Type&proto.OperatType_HuOperat != 0 && m.GetHu().GetOk():
+		seat.Win = true
+	case m.Type&proto.OperatType_GangOperat != 0 && m.GetGang().GetOk() && m.GetGang().GetGang() != nil:
+		gang := m.Gang.Gang
+		card := gang.Cards[0]
+		switch gang.Type {
+		case proto.GangType_MingGang:
+			claim(card)
+			seat.Waves = append(seat.Waves, &proto.Wave{Cards: []int32{card, card, card, card}, WaveType: proto.Wave_GangWave, GangType: gang.Type})
+			if me {
+				t.Cards = utils.Without(t.Cards, card, card, card)
+			}
+		case proto.GangType_AnGang:
+			seat.Waves = append(seat.Waves, &proto.Wave{Cards: []int32{card, card, card, card}, WaveType: proto.Wave_GangWave, GangType: gang.Type})
+			if me {
+				t.Cards = utils.Without(t.Cards, card, card, card, card)
+			}
+		default:
+			added := false
+			for _, wave := range seat.Waves {
+				if wave.Cards[0] == card && gang.Type == proto.GangType_BuGang {
+					wave.Cards = append(wave.Cards, card)
+					wave.WaveType, wave.GangType = proto.Wave_GangWave, gang.Type
+					added = true
+				}
+			}
+			if !added {
+				seat.Waves = append(seat.Waves, &proto.Wave{Cards: []int32{card}, WaveType: proto.Wave_GangWave, GangType: gang.Type})
+			}
+			if me {
+				t.Cards = utils.Without(t.Cards, card)
+			}
+		}
+		if me {
+			t.DrawCard = 0
+		}
+	case m.Type&proto.OperatType_PongOperat != 0 && m.GetPong().GetOk():
+		card := m.Pong.Card
+		claim(card)
+		seat.Waves = append(seat.Waves, &proto.Wave{Cards: []int32{card, card, card}, WaveType: proto.Wave_PongWave})
+		if me {
+			t.Cards = utils.Without(t.Cards, card, card)
+			t.DrawCard = 0
+		}
+	case m.Type&proto.OperatType_EatOperat != 0 && m.GetEat().GetOk() && m.GetEat().GetEat() != nil:
+		eat := m.Eat.Eat
+		claimed := utils.Without(eat.WaveCard, eat.HandCard...)
+		if len(claimed) == 1 {
+			claim(claimed[0])
+		}
+		seat.Waves = append(seat.Waves, &proto.Wave{Cards: eat.WaveCard, WaveType: proto.Wave_EatWave})
+		if me {
+			t.Cards = utils.Without(t.Cards, eat.HandCard...)
+			t.DrawCard = 0
+		}
+	case m.Type&proto.OperatType_DropOperat != 0:
+		card := m.GetDrop().GetDisCard()
+		seat.Drops = append(seat.Drops, card)
+		t.LastDrop = m.Uid
+		if me {
+			t.Cards = utils.Without(t.Cards, card)
+			t.DrawCard = 0
+		}
+	case m.Type&proto.OperatType_DingQueOperat != 0:
+		seat.Que = m.GetDingQue().GetSuit()
+	}
+}
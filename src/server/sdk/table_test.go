@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"testing"
+
+	"server/proto"
+	"server/utils"
+)
+
+func operatMsg(uid uint64, operat proto.OperatType, fill func(m *proto.OperatMsg)) *proto.OperatMsg {
+	m := proto.NewOperatMsg()
+	m.Uid, m.Type = uid, operat
+	fill(m)
+	return m
+}
+
+func TestTableApply(t *testing.T) {
+	table := NewTable(1, "me")
+	table.Apply(&proto.UserJoinTableMsg{Tid: 100, Dealer: 1, Seats: []*proto.Seat{{Uid: 1, Pos: 1}, {Uid: 2, Name: "b", Pos: 2}}})
+	cards, _ := utils.ParseCards("1115m234p3455s")
+	deal := proto.NewOperatReq()
+	deal.Type = proto.OperatType_DealOperat
+	deal.DealReq.Cards = cards
+	table.Apply(deal)
+	if table.Tid != 100 || table.Hands != 1 || table.Seat(2).Name != "b" || len(table.Seats) != 2 {
+		t.Fatalf("table after deal: %+v", table)
+	}
+
+	draw := proto.NewOperatReq()
+	draw.Type = proto.OperatType_DrawOperat
+	draw.DrawReq.Card = 101
+	table.Apply(draw)
+	table.Apply(operatMsg(1, proto.OperatType_GangOperat, func(m *proto.OperatMsg) {
+		m.Gang = &proto.GangRsp{Ok: true, Gang: &proto.Gang{Cards: []int32{101}, Type: proto.GangType_AnGang}}
+	}))
+	table.Apply(operatMsg(1, proto.OperatType_DropOperat, func(m *proto.OperatMsg) { m.Drop.DisCard = 105 }))
+	// 对家碰了我打的5m, 又打了一张我来吃
+	table.Apply(operatMsg(2, proto.OperatType_PongOperat, func(m *proto.OperatMsg) { m.Pong = &proto.PongRsp{Ok: true, Card: 105} }))
+	table.Apply(operatMsg(2, proto.OperatType_DropOperat, func(m *proto.OperatMsg) { m.Drop.DisCard = 305 }))
+	table.Apply(operatMsg(1, proto.OperatType_EatOperat, func(m *proto.OperatMsg) {
+		m.Eat = &proto.EatRsp{Ok: true, Eat: &proto.Eat{WaveCard: []int32{303, 304, 305}, HandCard: []int32{303, 304}}}
+	}))
+	// 碰过的再补杠, 没成功的杠不算
+	table.Apply(operatMsg(2, proto.OperatType_GangOperat, func(m *proto.OperatMsg) {
+		m.Gang = &proto.GangRsp{Ok: true, Gang: &proto.Gang{Cards: []int32{105}, Type: proto.GangType_BuGang}}
+	}))
+	table.Apply(operatMsg(2, proto.OperatType_GangOperat, func(m *proto.OperatMsg) {
+		m.Gang = &proto.GangRsp{Ok: false, Gang: &proto.Gang{Cards: []int32{201}, Type: proto.GangType_AnGang}}
+	}))
+
+	me, other := table.Me(), table.Seat(2)
+	if got := utils.CardsNotation(table.Hand()); got != "234p55s" {
+		t.Errorf("hand %v, want 234p55s", got)
+	}
+	if len(me.Drops) != 0 || len(other.Drops) != 0 || table.LastDrop != 2 {
+		t.Errorf("drops %v %v, last drop %v", me.Drops, other.Drops, table.LastDrop)
+	}
+	if len(me.Waves) != 2 || utils.CardsNotation(me.Waves[1].Cards) != "345s" {
+		t.Errorf("my waves %v", me.Waves)
+	}
+	if len(other.Waves) != 1 || len(other.Waves[0].Cards) != 4 || other.Waves[0].GangType != proto.GangType_BuGang {
+		t.Errorf("other waves %v", other.Waves)
+	}
+
+	table.Apply(deal)
+	if table.Hands != 2 || len(table.Cards) != len(cards) || len(me.Waves) != 0 || len(other.Waves) != 0 {
+		t.Errorf("table after second deal: %+v", table)
+	}
+}
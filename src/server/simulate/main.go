@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jxbdlut/leaf/log"
+	"os"
+	"server/conf"
+	"server/game"
+	"server/game/area_manager"
+	"strings"
+)
+
+// 全机器人模拟, 在bin目录下运行, 和服务器读同一份配置和gamedata
+// 例: simulate -tables 1000 -hands 4 -area 1 -robots defensive,greedy,baseline,random
+
+var (
+	configPath = flag.String("config", "conf/server.json", "server config file, empty for defaults")
+	tables     = flag.Int("tables", 100, "number of tables")
+	hands      = flag.Int("hands", 1, "hands per table")
+	seed       = flag.Int64("seed", 1, "seed of the first table, table i uses seed+i")
+	areaId     = flag.Int("area", 0, "area id")
+	playerNum  = flag.Int("players", 0, "players per table, 0 for Game.DefaultPlayerNum")
+	robots     = flag.String("robots", "", "comma separated robot strategy per seat, empty for Game.TableRobot")
+	workers    = flag.Int("workers", 1, "tables simulated at the same time")
+	check      = flag.Bool("check", false, "check engine invariants after every action")
+	logLevel   = flag.String("log", "error", "log level, debug shows every operation")
+)
+
+func main() {
+	flag.Parse()
+	if *configPath != "" {
+		if err := conf.Load(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "load config %v: %v\n", *configPath, err)
+			os.Exit(2)
+		}
+	}
+	logger, err := log.New(*logLevel, "", 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	log.Export(logger)
+	if err := area_manager.Init(); err != nil {
+		log.Fatal("%v", err)
+	}
+
+	config := game.SimConfig{
+		Tables:    *tables,
+		Hands:     *hands,
+		Seed:      *seed,
+		Area:      uint16(*areaId),
+		PlayerNum: *playerNum,
+		Workers:   *workers,
+		Check:     *check,
+	}
+	if *robots != "" {
+		config.Robots = strings.Split(*robots, ",")
+	}
+	report, err := game.Simulate(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Print(report.String())
+	if len(report.Panics) > 0 {
+		os.Exit(1)
+	}
+}
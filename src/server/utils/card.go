@@ -0,0 +1,193 @@
+package utils
+
+// 牌的编码是 门*100+点数: 101-109万 201-209饼 301-309条 401-407東南西北中發白 501-508春夏秋冬梅兰竹菊.
+// 协议和Player里面还是[]int32, 规则里面算门和点数的时候换成Card, 统计张数的时候换成Hand
+
+type Suit int32
+
+const (
+	SuitHun    Suit = 0 // SeparateCards的第0组放混
+	SuitWan    Suit = 1
+	SuitBing   Suit = 2
+	SuitTiao   Suit = 3
+	SuitZi     Suit = 4
+	SuitFlower Suit = 5
+)
+
+func (s Suit) String() string {
+	return SuitStr(int32(s))
+}
+
+// 万饼条
+func (s Suit) IsNumber() bool {
+	return SuitWan <= s && s <= SuitTiao
+}
+
+// 这一门最大的点数
+func (s Suit) MaxRank() int32 {
+	switch s {
+	case SuitZi:
+		return 7
+	case SuitFlower:
+		return 8
+	}
+	return 9
+}
+
+type Card int32
+
+func NewCard(suit Suit, rank int32) Card {
+	return Card(int32(suit)*100 + rank)
+}
+
+func (c Card) Suit() Suit {
+	return Suit(c / 100)
+}
+
+func (c Card) Rank() int32 {
+	return int32(c % 10)
+}
+
+func (c Card) Valid() bool {
+	s := c.Suit()
+	return SuitWan <= s && s <= SuitFlower && 1 <= c.Rank() && c.Rank() <= s.MaxRank()
+}
+
+func (c Card) IsNumber() bool {
+	return c.Suit().IsNumber()
+}
+
+// 字牌: 東南西北中發白
+func (c Card) IsHonor() bool {
+	return c.Suit() == SuitZi
+}
+
+func (c Card) IsFlower() bool {
+	return c.Suit() == SuitFlower
+}
+
+// 幺九: 万饼条的一和九
+func (c Card) IsTerminal() bool {
+	return c.IsNumber() && (c.Rank() == 1 || c.Rank() == 9)
+}
+
+// 二五八, 258做将的玩法只有这些能做将
+func (c Card) Is258() bool {
+	r := c.Rank()
+	return c.IsNumber() && (r == 2 || r == 5 || r == 8)
+}
+
+// 同一门的下一张, 九万的下一张是一万, 白的下一张是東
+func (c Card) Next() Card {
+	if c.Rank() == c.Suit().MaxRank() {
+		return NewCard(c.Suit(), 1)
+	}
+	return c + 1
+}
+
+func (c Card) String() string {
+	return CardNotation(int32(c))
+}
+
+// 按门和点数统计的张数, 下标就是Suit和Rank, 第0门和第0点不用
+type Hand [6][10]int
+
+func NewHand(cards []int32) Hand {
+	var h Hand
+	for _, card := range cards {
+		h.Add(Card(card))
+	}
+	return h
+}
+
+func (h *Hand) Add(card Card) {
+	h[card.Suit()][card.Rank()]++
+}
+
+func (h *Hand) Remove(card Card) bool {
+	if h.Count(card) == 0 {
+		return false
+	}
+	h[card.Suit()][card.Rank()]--
+	return true
+}
+
+// 拿掉所有的card, 返回拿掉的张数, 用来把混单独拿出来
+func (h *Hand) RemoveAll(card Card) int {
+	num := h.Count(card)
+	h[card.Suit()][card.Rank()] = 0
+	return num
+}
+
+func (h *Hand) Count(card Card) int {
+	if card < 100 || card >= 600 {
+		return 0
+	}
+	return h[card.Suit()][card.Rank()]
+}
+
+func (h *Hand) SuitLen(s Suit) int {
+	num := 0
+	for _, n := range h[s][1:] {
+		num += n
+	}
+	return num
+}
+
+func (h *Hand) Len() int {
+	num := 0
+	for s := SuitWan; s <= SuitFlower; s++ {
+		num += h.SuitLen(s)
+	}
+	return num
+}
+
+// 一门的牌从小到大接在dst后面
+func (h *Hand) AppendSuit(dst []int32, s Suit) []int32 {
+	for r := int32(1); r <= s.MaxRank(); r++ {
+		for i := 0; i < h[s][r]; i++ {
+			dst = append(dst, int32(NewCard(s, r)))
+		}
+	}
+	return dst
+}
+
+// 所有的牌从小到大, 转回协议用的[]int32
+func (h *Hand) Cards() []int32 {
+	cards := make([]int32, 0, h.Len())
+	for s := SuitWan; s <= SuitFlower; s++ {
+		cards = h.AppendSuit(cards, s)
+	}
+	return cards
+}
+
+// 往排好序的cards里插一张牌, 返回新的切片, 不改原来的
+func InsertCard(cards []int32, card int32) []int32 {
+	result := make([]int32, 0, len(cards)+1)
+	i := 0
+	for i < len(cards) && cards[i] <= card {
+		i++
+	}
+	result = append(result, cards[:i]...)
+	result = append(result, card)
+	return append(result, cards[i:]...)
+}
+
+// 去掉cards里面的几张牌(每个参数去掉一张, 最多四张), 返回新的切片, 不改原来的
+func Without(cards []int32, remove ...int32) []int32 {
+	result := make([]int32, 0, len(cards))
+	var removed [4]bool
+	for _, card := range cards {
+		skip := false
+		for i, r := range remove {
+			if !removed[i] && card == r {
+				removed[i], skip = true, true
+				break
+			}
+		}
+		if !skip {
+			result = append(result, card)
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestCard(t *testing.T) {
+	cases := []struct {
+		card                          Card
+		suit                          Suit
+		rank                          int32
+		valid, honor, terminal, is258 bool
+		next                          Card
+	}{
+		{101, SuitWan, 1, true, false, true, false, 102},
+		{109, SuitWan, 9, true, false, true, false, 101},
+		{205, SuitBing, 5, true, false, false, true, 206},
+		{308, SuitTiao, 8, true, false, false, true, 309},
+		{401, SuitZi, 1, true, true, false, false, 402},
+		{407, SuitZi, 7, true, true, false, false, 401},
+		{408, SuitZi, 8, false, true, false, false, 401},
+		{508, SuitFlower, 8, true, false, false, false, 501},
+		{100, SuitWan, 0, false, false, false, false, 101},
+	}
+	for _, c := range cases {
+		if c.card.Suit() != c.suit || c.card.Rank() != c.rank {
+			t.Errorf("%d: suit %v rank %v, want %v %v", c.card, c.card.Suit(), c.card.Rank(), c.suit, c.rank)
+		}
+		if c.card.Valid() != c.valid || c.card.IsHonor() != c.honor || c.card.IsTerminal() != c.terminal || c.card.Is258() != c.is258 {
+			t.Errorf("%d: valid %v honor %v terminal %v 258 %v", c.card, c.card.Valid(), c.card.IsHonor(), c.card.IsTerminal(), c.card.Is258())
+		}
+		if c.valid && c.card.Next() != c.next {
+			t.Errorf("%d: next %d, want %d", c.card, c.card.Next(), c.next)
+		}
+	}
+	if NewCard(SuitTiao, 7) != 307 || Card(307).String() != "7s" {
+		t.Errorf("NewCard(SuitTiao, 7) = %v", NewCard(SuitTiao, 7))
+	}
+}
+
+func TestHand(t *testing.T) {
+	cards, _ := ParseCards("1199m5p77z3f")
+	h := NewHand(cards)
+	if h.Len() != 8 || h.SuitLen(SuitWan) != 4 || h.Count(101) != 2 || h.Count(408) != 0 || h.Count(0) != 0 {
+		t.Errorf("NewHand(%v) = %v", CardsNotation(cards), h)
+	}
+	if !h.Remove(205) || h.Remove(205) || h.RemoveAll(101) != 2 {
+		t.Errorf("Remove: %v", h)
+	}
+	h.Add(409)
+	h.Add(402)
+	if got := CardsNotation(h.Cards()); got != "99m277z3f" {
+		t.Errorf("Cards() = %v", got)
+	}
+}
+
+func TestSeparateCards(t *testing.T) {
+	cards, _ := ParseHand("31m55p22z + hun x2", 309)
+	result := SeparateCards(cards, 309)
+	want := [5]string{"99s", "13m", "55p", "", "22z"}
+	for i := range result {
+		if got := CardsNotation(result[i]); got != want[i] {
+			t.Errorf("SeparateCards[%v] = %v, want %v", i, got, want[i])
+		}
+	}
+	// 往一门里加牌不能改到别的门
+	_ = append(result[1], 109)
+	if CardsNotation(result[2]) != "55p" {
+		t.Errorf("append to suit 1 changed suit 2: %v", result[2])
+	}
+	if begin, end := SearchRange(result[1]); begin != 101 || end != 105 {
+		t.Errorf("SearchRange(13m) = %v %v", begin, end)
+	}
+	if begin, end := SearchRange(result[4]); begin != 402 || end != 402 {
+		t.Errorf("SearchRange(22z) = %v %v", begin, end)
+	}
+}
+
+func TestInsertWithout(t *testing.T) {
+	cards, _ := ParseCards("1134m")
+	if got := CardsNotation(InsertCard(cards, 102)); got != "11234m" {
+		t.Errorf("InsertCard = %v", got)
+	}
+	if got := InsertCard(cards, 109); got[4] != 109 {
+		t.Errorf("InsertCard = %v", got)
+	}
+	if got := CardsNotation(Without(cards, 101, 104, 105)); got != "13m" {
+		t.Errorf("Without = %v", got)
+	}
+	if CardsNotation(cards) != "1134m" {
+		t.Errorf("cards changed: %v", cards)
+	}
+}
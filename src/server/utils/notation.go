@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 牌的简写: 数字在前, 门在后, m万 p饼 s条 z字(1-7是東南西北中發白) f花(1-8是春夏秋冬梅兰竹菊),
+// 例如 "123m456p789s1234567z". 也认Unicode的麻将字符(🀇🀈🀉...), 两种写法可以混在一起.
+// 混写成 "+ hun x2" 或者 🀪, 解析的时候换成当局的混牌
+const (
+	notation_suits = "mpszf"
+	HunGlyph       = '🀪'
+)
+
+// 日志里一串牌的写法, 见CardsStr
+const (
+	CardStyleChinese  = "chinese"
+	CardStyleNotation = "notation"
+	CardStyleGlyph    = "glyph"
+)
+
+var LogCardStyle = CardStyleChinese
+
+var (
+	card_glyphs  = make(map[int32]rune)
+	glyph_cards  = make(map[rune]int32)
+	suit_letters = map[rune]int32{'m': 1, 'p': 2, 's': 3, 'z': 4, 'f': 5}
+)
+
+func init() {
+	// Unicode的麻将牌从U+1F000开始: 東南西北中發白, 一到九万, 一到九条, 一到九饼, 梅兰竹菊, 春夏秋冬
+	add := func(card int32, glyph rune) {
+		card_glyphs[card] = glyph
+		glyph_cards[glyph] = card
+	}
+	for v := int32(1); v <= 9; v++ {
+		if v <= 7 {
+			add(400+v, 0x1F000+rune(v-1))
+		}
+		add(100+v, 0x1F007+rune(v-1))
+		add(300+v, 0x1F010+rune(v-1))
+		add(200+v, 0x1F019+rune(v-1))
+	}
+	for v := int32(1); v <= 4; v++ {
+		add(504+v, 0x1F022+rune(v-1))
+		add(500+v, 0x1F026+rune(v-1))
+	}
+}
+
+// 单张牌的简写, 腾空飘将这些特殊的听牌用中文
+func CardNotation(card int32) string {
+	if card < 100 {
+		return CardStr(card)
+	}
+	return fmt.Sprintf("%v%c", card%10, notation_suits[card/100-1])
+}
+
+// 同一门的牌合在一起写, 按门和点数排好
+func CardsNotation(cards []int32) string {
+	var buf []byte
+	for t := int32(1); t <= 5; t++ {
+		n := len(buf)
+		for v := int32(1); v <= 9; v++ {
+			for _, card := range cards {
+				if card == t*100+v {
+					buf = append(buf, byte('0'+v))
+				}
+			}
+		}
+		if len(buf) > n {
+			buf = append(buf, notation_suits[t-1])
+		}
+	}
+	return string(buf)
+}
+
+// 手牌的简写, 混单独写在后面, 例如 "123m456p789s11z + hun x2"
+func HandNotation(cards []int32, hun_card int32) string {
+	var others []int32
+	hun_num := 0
+	for _, card := range cards {
+		if hun_card != 0 && card == hun_card {
+			hun_num++
+		} else {
+			others = append(others, card)
+		}
+	}
+	str := CardsNotation(others)
+	if hun_num > 0 {
+		str = strings.TrimSpace(fmt.Sprintf("%v + hun x%v", str, hun_num))
+	}
+	return str
+}
+
+func CardGlyph(card int32) string {
+	if glyph, ok := card_glyphs[card]; ok {
+		return string(glyph)
+	}
+	return CardStr(card)
+}
+
+// 按原来的顺序一张一张画, hun_card不为0的时候混画成🀪
+func CardsGlyph(cards []int32, hun_card int32) string {
+	var buf []rune
+	for _, card := range cards {
+		if hun_card != 0 && card == hun_card {
+			buf = append(buf, HunGlyph)
+		} else if glyph, ok := card_glyphs[card]; ok {
+			buf = append(buf, glyph)
+		}
+	}
+	return string(buf)
+}
+
+// 解析简写或者Unicode字符, 不认混
+func ParseCards(s string) ([]int32, error) {
+	return ParseHand(s, 0)
+}
+
+// 解析手牌, "+ hun xN" 和 🀪 都换成hun_card, 不在原来的位置上, 统一放在最后
+func ParseHand(s string, hun_card int32) ([]int32, error) {
+	hun_num := 0
+	if i := strings.Index(s, "+"); i >= 0 {
+		fields := strings.Fields(s[i+1:])
+		if len(fields) != 2 || fields[0] != "hun" || !strings.HasPrefix(fields[1], "x") {
+			return nil, fmt.Errorf("want \"+ hun xN\" in %q", s)
+		}
+		num, err := strconv.Atoi(fields[1][1:])
+		if err != nil || num < 0 {
+			return nil, fmt.Errorf("invalid hun num in %q", s)
+		}
+		hun_num, s = num, s[:i]
+	}
+	var cards, values []int32
+	for _, r := range s {
+		if card, ok := glyph_cards[r]; ok || r == HunGlyph {
+			if len(values) != 0 {
+				return nil, fmt.Errorf("values without suit before %c in %q", r, s)
+			}
+			if r == HunGlyph {
+				hun_num++
+			} else {
+				cards = append(cards, card)
+			}
+			continue
+		}
+		switch {
+		case r == ' ' || r == ',':
+		case '1' <= r && r <= '9':
+			values = append(values, int32(r-'0'))
+		default:
+			t, ok := suit_letters[r]
+			if !ok {
+				return nil, fmt.Errorf("unknown suit %q in %q", r, s)
+			}
+			if len(values) == 0 {
+				return nil, fmt.Errorf("suit %q without values in %q", r, s)
+			}
+			for _, v := range values {
+				if (t == 4 && v > 7) || (t == 5 && v > 8) {
+					return nil, fmt.Errorf("invalid card %v%c in %q", v, r, s)
+				}
+				cards = append(cards, t*100+v)
+			}
+			values = values[:0]
+		}
+	}
+	if len(values) != 0 {
+		return nil, fmt.Errorf("values without suit in %q", s)
+	}
+	if hun_num > 0 && hun_card == 0 {
+		return nil, fmt.Errorf("hun x%v without hun card in %q", hun_num, s)
+	}
+	for i := 0; i < hun_num; i++ {
+		cards = append(cards, hun_card)
+	}
+	return cards, nil
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseHand(t *testing.T) {
+	cases := []struct {
+		hand  string
+		cards string
+	}{
+		{"123m456p789s11z", "123m456p789s11z"},
+		{"11z 987s 654p 321m", "123m456p789s11z"},
+		{"123m456p789s11z + hun x2", "123m456p789s11z + hun x2"},
+		{"+ hun x4", "+ hun x4"},
+		{"12f", "12f"},
+		{"🀇🀈🀉🀜🀝🀞🀘🀗🀖🀀🀀", "123m456p789s11z"},
+		{"🀇🀈🀉456p789s🀀🀪🀪", "123m456p789s1z + hun x2"},
+		{"🀄🀅🀆🀢🀦", "67z15f + hun x1"},
+	}
+	for _, c := range cases {
+		cards, err := ParseHand(c.hand, 405)
+		if err != nil {
+			t.Errorf("ParseHand(%q): %v", c.hand, err)
+			continue
+		}
+		if got := HandNotation(cards, 405); got != c.cards {
+			t.Errorf("ParseHand(%q) = %v, want %v", c.hand, got, c.cards)
+		}
+	}
+	for _, hand := range []string{"123", "m", "8z", "9f", "12x", "11z + hun", "11z + hun 2", "1🀇m"} {
+		if _, err := ParseHand(hand, 405); err == nil {
+			t.Errorf("ParseHand(%q) want error", hand)
+		}
+	}
+	if _, err := ParseCards("11z + hun x1"); err == nil {
+		t.Error("ParseCards with hun want error")
+	}
+}
+
+// 简写和Unicode字符都能原样解析回来, 混换成标记之后也一样
+func TestNotationRoundTrip(t *testing.T) {
+	hun_card := int32(205)
+	var cards []int32
+	for card := range CardsMap {
+		if card > 100 {
+			cards = append(cards, card, card)
+		}
+	}
+	SortCards(cards, 0)
+	for _, hun := range []int32{0, hun_card} {
+		notation, err := ParseHand(HandNotation(cards, hun), hun)
+		if err != nil || CardsNotation(notation) != CardsNotation(cards) {
+			t.Errorf("notation round trip: %v, %v", CardsNotation(notation), err)
+		}
+		glyphs, err := ParseHand(CardsGlyph(cards, hun), hun)
+		if err != nil || CardsNotation(glyphs) != CardsNotation(cards) {
+			t.Errorf("glyph round trip: %v, %v", CardsNotation(glyphs), err)
+		}
+	}
+	for _, card := range cards {
+		if parsed, err := ParseCards(CardNotation(card)); err != nil || len(parsed) != 1 || parsed[0] != card {
+			t.Errorf("CardNotation(%v) = %v", card, CardNotation(card))
+		}
+		if parsed, err := ParseCards(CardGlyph(card)); err != nil || len(parsed) != 1 || parsed[0] != card {
+			t.Errorf("CardGlyph(%v) = %v", card, CardGlyph(card))
+		}
+	}
+}
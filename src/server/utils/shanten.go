@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"sync"
+)
+
+// 向听数计算, 给机器人用. -1表示已经胡了, 0表示听牌
+// 混牌按万能牌近似, 每张混牌向听数减一
+
+type meldOption struct {
+	meld   int // 面子数
+	taatsu int // 搭子数
+}
+
+var (
+	AllCardKinds     []int32
+	suitOptionsCache = make(map[int][]meldOption)
+	suitOptionsMutex sync.Mutex
+)
+
+func init() {
+	for suit := int32(1); suit <= 3; suit++ {
+		for point := int32(1); point <= 9; point++ {
+			AllCardKinds = append(AllCardKinds, suit*100+point)
+		}
+	}
+	for point := int32(1); point <= 7; point++ {
+		AllCardKinds = append(AllCardKinds, 400+point)
+	}
+}
+
+func countCards(cards []int32, hun_card int32) ([5][10]int, int) {
+	h := NewHand(cards)
+	hun := 0
+	if hun_card != 0 {
+		hun = h.RemoveAll(Card(hun_card))
+	}
+	// 花牌不算
+	var counts [5][10]int
+	copy(counts[:], h[:5])
+	return counts, hun
+}
+
+// meld_num是已经吃碰杠的组数, 有吃碰杠的时候不算七对
+func Shanten(cards []int32, hun_card int32, meld_num int) int {
+	counts, hun := countCards(cards, hun_card)
+	shanten := shantenNormal(&counts, meld_num)
+	if meld_num == 0 {
+		if pair7 := shantenPair7(&counts); pair7 < shanten {
+			shanten = pair7
+		}
+	}
+	shanten -= hun
+	if shanten < -1 {
+		shanten = -1
+	}
+	return shanten
+}
+
+// 进张: 摸到之后向听数变小的牌, remain返回这张牌还有几张没见过
+func Acceptance(cards []int32, hun_card int32, meld_num int, remain func(card int32) int) (int, []int32) {
+	shanten := Shanten(cards, hun_card, meld_num)
+	total := 0
+	var kinds []int32
+	for _, card := range AllCardKinds {
+		num := remain(card)
+		if num <= 0 {
+			continue
+		}
+		if Shanten(append(Copy(cards), card), hun_card, meld_num) < shanten {
+			total += num
+			kinds = append(kinds, card)
+		}
+	}
+	return total, kinds
+}
+
+// 四张一样的算两对, 和各区域的七对一样
+func shantenPair7(counts *[5][10]int) int {
+	pairs := 0
+	for suit := 1; suit <= 4; suit++ {
+		for point := 1; point <= 9; point++ {
+			pairs += counts[suit][point] / 2
+		}
+	}
+	return 6 - pairs
+}
+
+// 先选将, 再把每门拆成面子和搭子
+func shantenNormal(counts *[5][10]int, meld_num int) int {
+	best := shantenWithPair(counts, meld_num, 0)
+	for suit := 1; suit <= 4; suit++ {
+		for point := 1; point <= 9; point++ {
+			if counts[suit][point] >= 2 {
+				counts[suit][point] -= 2
+				if shanten := shantenWithPair(counts, meld_num, 1); shanten < best {
+					best = shanten
+				}
+				counts[suit][point] += 2
+			}
+		}
+	}
+	return best
+}
+
+func shantenWithPair(counts *[5][10]int, meld_num int, pair int) int {
+	combos := []meldOption{{}}
+	for suit := 1; suit <= 4; suit++ {
+		var next []meldOption
+		for _, combo := range combos {
+			for _, option := range suitOptions(counts[suit], suit == 4) {
+				next = append(next, meldOption{combo.meld + option.meld, combo.taatsu + option.taatsu})
+			}
+		}
+		combos = paretoOptions(next)
+	}
+	best := 8
+	for _, combo := range combos {
+		meld, taatsu := combo.meld+meld_num, combo.taatsu
+		if meld > 4 {
+			meld = 4
+		}
+		if meld+taatsu > 4 {
+			taatsu = 4 - meld
+		}
+		if shanten := 8 - 2*meld - taatsu - pair; shanten < best {
+			best = shanten
+		}
+	}
+	return best
+}
+
+// 一门牌所有拆法里不被别的拆法完全压住的(面子数, 搭子数)
+func suitOptions(counts [10]int, honor bool) []meldOption {
+	key := 0
+	for point := 9; point >= 1; point-- {
+		key = key*5 + counts[point]
+	}
+	key = key * 2
+	if honor {
+		key++
+	}
+	suitOptionsMutex.Lock()
+	options, ok := suitOptionsCache[key]
+	suitOptionsMutex.Unlock()
+	if ok {
+		return options
+	}
+
+	var all []meldOption
+	var split func(point int, meld int, taatsu int)
+	split = func(point int, meld int, taatsu int) {
+		for point <= 9 && counts[point] == 0 {
+			point++
+		}
+		if point > 9 {
+			all = append(all, meldOption{meld, taatsu})
+			return
+		}
+		if counts[point] >= 3 {
+			counts[point] -= 3
+			split(point, meld+1, taatsu)
+			counts[point] += 3
+		}
+		if !honor && point <= 7 && counts[point+1] > 0 && counts[point+2] > 0 {
+			counts[point]--
+			counts[point+1]--
+			counts[point+2]--
+			split(point, meld+1, taatsu)
+			counts[point]++
+			counts[point+1]++
+			counts[point+2]++
+		}
+		if counts[point] >= 2 {
+			counts[point] -= 2
+			split(point, meld, taatsu+1)
+			counts[point] += 2
+		}
+		if !honor && point <= 8 && counts[point+1] > 0 {
+			counts[point]--
+			counts[point+1]--
+			split(point, meld, taatsu+1)
+			counts[point]++
+			counts[point+1]++
+		}
+		if !honor && point <= 7 && counts[point+2] > 0 {
+			counts[point]--
+			counts[point+2]--
+			split(point, meld, taatsu+1)
+			counts[point]++
+			counts[point+2]++
+		}
+		// 孤张
+		counts[point]--
+		split(point, meld, taatsu)
+		counts[point]++
+	}
+	split(1, 0, 0)
+	options = paretoOptions(all)
+
+	suitOptionsMutex.Lock()
+	suitOptionsCache[key] = options
+	suitOptionsMutex.Unlock()
+	return options
+}
+
+func paretoOptions(options []meldOption) []meldOption {
+	var result []meldOption
+	for i, option := range options {
+		dominated := false
+		for j, other := range options {
+			if i == j {
+				continue
+			}
+			if other.meld >= option.meld && other.taatsu >= option.taatsu && (other != option || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, option)
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShanten(t *testing.T) {
+	cases := []struct {
+		hand     string
+		hun_card int32
+		meld_num int
+		want     int
+	}{
+		{"123m456p789s111z22z", 0, 0, -1},
+		{"123m456p789s1122z", 0, 0, 0},
+		{"123m456p789s1z23z", 0, 0, 2},
+		// 七对比四组面子一对将更近
+		{"1133m5577p99s114z", 0, 0, 0},
+		{"19m19p19s1234567z", 0, 0, 6},
+		{"1111m22p33p55s77s11z", 0, 0, -1},
+		{"1111m22p33p55s77s1z", 0, 0, 0},
+		// 有吃碰杠不算七对
+		{"1133m5577p99s", 0, 1, 2},
+		// 混牌按万能牌, 一张混向听数减一
+		{"123m456p78s11z57z + hun x1", 309, 0, 1},
+	}
+	for _, c := range cases {
+		cards, err := ParseHand(c.hand, c.hun_card)
+		if err != nil {
+			t.Fatalf("ParseHand(%q): %v", c.hand, err)
+		}
+		if got := Shanten(cards, c.hun_card, c.meld_num); got != c.want {
+			t.Errorf("Shanten(%v, meld:%v) = %v, want %v", c.hand, c.meld_num, got, c.want)
+		}
+	}
+}
+
+func TestAcceptance(t *testing.T) {
+	cards, _ := ParseCards("123m456p789s1122z")
+	visible := NewHand(cards)
+	cases := []struct {
+		remain func(card int32) int
+		total  int
+		kinds  string
+	}{
+		{func(card int32) int { return 4 - visible.Count(Card(card)) }, 4, "12z"},
+		// 见光了的牌不算进张
+		{func(card int32) int {
+			if card == 401 {
+				return 0
+			}
+			return 4 - visible.Count(Card(card))
+		}, 2, "2z"},
+	}
+	for _, c := range cases {
+		total, kinds := Acceptance(cards, 0, 0, c.remain)
+		if total != c.total || CardsNotation(kinds) != c.kinds {
+			t.Errorf("Acceptance(%v) = %v %v, want %v %v", CardsNotation(cards), total, CardsNotation(kinds), c.total, c.kinds)
+		}
+	}
+}
+
+func TestSuitOptions(t *testing.T) {
+	cases := []struct {
+		counts [10]int
+		honor  bool
+		want   []meldOption
+	}{
+		// 面子和搭子分开比, 少一个面子多一个搭子的拆法也要留下
+		{[10]int{0, 1, 1, 1}, false, []meldOption{{1, 0}, {0, 1}}},
+		// 字牌不能组成顺子和搭子
+		{[10]int{0, 1, 1, 1}, true, []meldOption{{0, 0}}},
+		{[10]int{0, 2, 1, 1}, false, []meldOption{{1, 0}, {0, 2}}},
+		{[10]int{0, 1, 0, 1, 0, 0, 0, 0, 1, 1}, false, []meldOption{{0, 2}}},
+		{[10]int{0, 3, 1, 1}, false, []meldOption{{1, 1}, {0, 2}}},
+	}
+	for _, c := range cases {
+		got := suitOptions(c.counts, c.honor)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("suitOptions(%v, honor:%v) = %v, want %v", c.counts, c.honor, got, c.want)
+		}
+		// 第二次从缓存里取, 结果一样
+		size := len(suitOptionsCache)
+		if again := suitOptions(c.counts, c.honor); !reflect.DeepEqual(again, got) || len(suitOptionsCache) != size {
+			t.Errorf("suitOptions(%v, honor:%v) cached = %v, cache size %v, want %v", c.counts, c.honor, again, len(suitOptionsCache), size)
+		}
+	}
+}
+
+func TestParetoOptions(t *testing.T) {
+	cases := []struct {
+		options []meldOption
+		want    []meldOption
+	}{
+		{[]meldOption{{1, 0}, {0, 1}, {0, 0}, {1, 0}, {1, 1}}, []meldOption{{1, 1}}},
+		// 一样的只留第一个, 互相压不住的都留下
+		{[]meldOption{{2, 0}, {1, 2}, {1, 1}, {2, 0}}, []meldOption{{2, 0}, {1, 2}}},
+		{[]meldOption{{0, 0}}, []meldOption{{0, 0}}},
+	}
+	for _, c := range cases {
+		if got := paretoOptions(c.options); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("paretoOptions(%v) = %v, want %v", c.options, got, c.want)
+		}
+	}
+}
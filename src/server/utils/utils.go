@@ -31,10 +31,18 @@ var (
 		301: "一条", 302: "二条", 303: "三条", 304: "四条", 305: "五条", 306: "六条", 307: "七条", 308: "八条",
 		309: "九条",
 		401: "東", 402: "南", 403: "西", 404: "北", 405: "中", 406: "發", 407: "白",
+		501: "春", 502: "夏", 503: "秋", 504: "冬", 505: "梅", 506: "兰", 507: "竹", 508: "菊",
 		1: "腾空", 2: "飘将", 3: "飘门", 4: "风一色"}
+	SuitsMap = map[int32]string{1: "万", 2: "饼", 3: "条", 4: "字", 5: "花"}
 )
 
 func CardsStr(cards []int32) string {
+	switch LogCardStyle {
+	case CardStyleNotation:
+		return "[" + CardsNotation(cards) + "]"
+	case CardStyleGlyph:
+		return "[" + CardsGlyph(cards, 0) + "]"
+	}
 	var str_cards []string
 	for _, card := range cards {
 		str_cards = append(str_cards, CardsMap[card])
@@ -46,6 +54,15 @@ func CardStr(card int32) string {
 	return CardsMap[card]
 }
 
+func SuitStr(suit int32) string {
+	return SuitsMap[suit]
+}
+
+// 花牌不进手牌, 摸到之后要补花
+func IsFlower(card int32) bool {
+	return Card(card).IsFlower()
+}
+
 func Count(cards []int32, card int32) int {
 	count := 0
 	for _, c := range cards {
@@ -80,39 +97,36 @@ func Copy(cards []int32) []int32 {
 	return cards_copy
 }
 
+// 同一门的牌摸哪些可能有用: 万饼条是最小的往下两张到最大的往上两张, 字牌只看有的
 func SearchRange(cards []int32) (int32, int32) {
-	m := cards[0] / 100
-	begin := int32(100*m + 10)
-	end := int32(100 * m)
-
+	s := Card(cards[0]).Suit()
 	if len(cards) == 2 && cards[0] == cards[1] {
 		return cards[0], cards[1]
 	}
 
+	begin, end := NewCard(s, 10), NewCard(s, 0)
 	for _, card := range cards {
-		if card < begin {
-			begin = card
+		if Card(card) < begin {
+			begin = Card(card)
 		}
-		if card > end {
-			end = card
+		if Card(card) > end {
+			end = Card(card)
 		}
 	}
-	if m == 4 {
-		return begin, end
+	if !s.IsNumber() {
+		return int32(begin), int32(end)
 	}
-	if begin-2 < 100*m+1 {
-		begin = 100*m + 1
+	if begin.Rank() <= 3 {
+		begin = NewCard(s, 1)
 	} else {
 		begin = begin - 2
 	}
-
-	if end+2 > 100*m+9 {
-		end = 100*m + 9
+	if end.Rank() >= 7 {
+		end = NewCard(s, 9)
 	} else {
 		end = end + 2
 	}
-
-	return begin, end
+	return int32(begin), int32(end)
 }
 
 func DelCountCard(cards []int32, card int32, count int) []int32 {
@@ -141,19 +155,28 @@ func DelCard(cards []int32, card1 int32, card2 int32, card3 int32) []int32 {
 	return cards
 }
 
+// 按门分开, 第0组是混, 每门从小到大. 先按张数统计再按顺序取出来, 不用排序, 所有的组共用一块内存
 func SeparateCards(cards []int32, hun_card int32) [5][]int32 {
 	var result = [5][]int32{}
-	for _, card := range cards {
-		m := int(0)
-		if card != hun_card {
-			m = int(card) / 100
-		} else {
-			m = 0
-		}
-		result[m] = append(result[m], card)
+	h := NewHand(cards)
+	hun_num := 0
+	if hun_card != 0 {
+		hun_num = h.RemoveAll(Card(hun_card))
 	}
-	for _, cards := range result {
-		SortCards(cards, hun_card)
+	all := make([]int32, 0, len(cards))
+	for i := 0; i < hun_num; i++ {
+		all = append(all, hun_card)
+	}
+	if hun_num > 0 {
+		result[0] = all[:hun_num:hun_num]
+	}
+	for s := SuitWan; s <= SuitZi; s++ {
+		begin := len(all)
+		all = h.AppendSuit(all, s)
+		// 限定容量, 调用的人往某一门里append的时候不会改到下一门
+		if len(all) > begin {
+			result[s] = all[begin:len(all):len(all)]
+		}
 	}
 	return result
 }
@@ -199,8 +222,8 @@ func DropSingle(separate_result [5][]int32) int32 {
 		}
 	}
 
-	for i := 1; i < 4; i++ {
-		min_card, max_card := int32(i*100+1), int32(i*100+9)
+	for i := SuitWan; i <= SuitTiao; i++ {
+		min_card, max_card := int32(NewCard(i, 1)), int32(NewCard(i, 9))
 		if Count(separate_result[i], min_card) == 1 && Count(separate_result[i], min_card+1) == 0 && Count(separate_result[i], min_card+2) == 0 {
 			return min_card
 		}
@@ -209,7 +232,7 @@ func DropSingle(separate_result [5][]int32) int32 {
 		}
 	}
 
-	for i := 1; i < 4; i++ {
+	for i := SuitWan; i <= SuitTiao; i++ {
 		for _, card := range separate_result[i] {
 			if Count(separate_result[i], card) > 1 {
 				continue
@@ -229,8 +252,7 @@ func DropNot258(cards []int32, hun_card int32) int32 {
 		if card == hun_card {
 			continue
 		}
-		t, v := card/100, card%10
-		if t == 4 || v != 2 && v != 5 && v != 8 {
+		if !Card(card).Is258() {
 			return card
 		}
 	}
@@ -242,8 +264,7 @@ func DropNotWind(cards []int32, hun_card int32) int32 {
 		if card == hun_card {
 			continue
 		}
-		t := card / 100
-		if t != 4 {
+		if !Card(card).IsHonor() {
 			return card
 		}
 	}